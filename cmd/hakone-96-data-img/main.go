@@ -1,10 +1,10 @@
 package main
 
 import (
-	"bufio"
-	"encoding/json"
+	"flag"
 	"fmt"
 	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/mike-neck/go-hakone-qualification/hakone/repository"
 	"github.com/pkg/errors"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
@@ -12,17 +12,18 @@ import (
 	"gonum.org/v1/plot/vg"
 	"image/color"
 	"log"
-	"os"
+	"time"
 )
 
 func main() {
-	file, err := os.Open("data/hakone-96-personal.jsonl")
+	editionNumber := flag.Int("edition", hakone.DefaultEditionNumber, "edition number of the race")
+	flag.Parse()
+	edition := hakone.NewEdition(*editionNumber)
+
+	repo, err := repository.LoadEdition(edition)
 	if err != nil {
-		log.Fatalln("failed to open file: data/hakone-96-personal.jsonl by ", err)
+		log.Fatalln("failed to load data of edition:", edition.Number, "by ", err)
 	}
-	defer func() {
-		_ = file.Close()
-	}()
 
 	plotImg, err := plot.New()
 	if err != nil {
@@ -38,29 +39,32 @@ func main() {
 	grid.Horizontal.Color = color.RGBA{R: 21, G: 21, B: 43, A: 0}
 	plotImg.Add(grid)
 
-	teamPlots := map[hakone.TeamName]*TeamPlot{
-		"東京国際大学": NewTeamPlot("Tokyo Kokusai Univ", 0, 12, 192),
-		"山梨学院大学": NewTeamPlot("Yamanashi Gakuin Univ", 21, 21, 127),
-		//"筑波大学": NewTeamPlot("Tsukuba Univ", 13, 169, 169),
-		"麗澤大学":  NewTeamPlot("Reitaku Univ", 192, 34, 0),
-		"中央大学":  NewTeamPlot("Chuo Univ", 62, 62, 0),
-		"上武大学":  NewTeamPlot("Joubu Univ", 168, 0, 194),
-		"早稲田大学": NewTeamPlot("Waseda Univ", 62, 52, 10),
-		"駿河台大学": NewTeamPlot("Surugadai Univ", 10, 14, 86),
-	}
-
-	scanner := bufio.NewScanner(file)
-	for i := 0; scanner.Scan(); i++ {
-		bytes := scanner.Bytes()
-		var record hakone.Record
-		err := json.Unmarshal(bytes, &record)
-		if err != nil {
-			fmt.Println("error at line:", i+1, "error: ", err, "json: ", string(bytes))
-			continue
+	registry := hakone.DefaultTeamRegistry()
+	teams := make([]hakone.Team, 0)
+	teamPlots := make(map[int]*TeamPlot)
+	addTeamPlot := func(name string, red, green, blue uint8) {
+		team, ok := registry.Resolve(name)
+		if !ok {
+			log.Fatalln("unknown team:", name)
 		}
-
-		if p, ok := teamPlots[record.Team]; ok && p.Index <= 10 {
-			p.Append(record)
+		teams = append(teams, team)
+		teamPlots[team.Id] = NewTeamPlot(edition, team.EnglishName, red, green, blue)
+	}
+	addTeamPlot("東京国際大学", 0, 12, 192)
+	addTeamPlot("山梨学院大学", 21, 21, 127)
+	//addTeamPlot("筑波大学", 13, 169, 169)
+	addTeamPlot("麗澤大学", 192, 34, 0)
+	addTeamPlot("中央大学", 62, 62, 0)
+	addTeamPlot("上武大学", 168, 0, 194)
+	addTeamPlot("早稲田大学", 62, 52, 10)
+	addTeamPlot("駿河台大学", 10, 14, 86)
+
+	for _, team := range teams {
+		p := teamPlots[team.Id]
+		for _, record := range repo.FindTop10FinishTimeRecordsByTeamName(team.RecordName()) {
+			if p.Index <= edition.ScoredRunnersPerTeam {
+				p.Append(record)
+			}
 		}
 	}
 
@@ -81,44 +85,54 @@ func main() {
 		log.Fatalln("failed to add points to plot, cause:", err)
 	}
 
-	if err = plotImg.Save(1440, 810, "build/hakone-96-img.png"); err != nil {
+	if err = plotImg.Save(1440, 810, fmt.Sprintf("build/hakone-%d-img.png", edition.Number)); err != nil {
 		log.Fatalln("failed to save file", err)
 	}
 }
 
+// Tick labels the axis of seconds in the time notation.
 type Tick struct{}
 
 func (Tick) Ticks(min, max float64) []plot.Tick {
-	return plot.DefaultTicks{}.Ticks(min, max)
+	ticks := plot.DefaultTicks{}.Ticks(min, max)
+	for i, tick := range ticks {
+		if tick.Label == "" {
+			continue
+		}
+		ticks[i].Label = hakone.FromDuration(time.Duration(tick.Value * float64(time.Second))).String()
+	}
+	return ticks
 }
 
 type SinglePlot struct {
 	Index int
-	Sum   int
+	Sum   hakone.Time
 }
 
 func (sp *SinglePlot) ToPlot() plotter.XY {
 	return plotter.XY{
 		X: float64(sp.Index),
-		Y: float64(sp.Sum),
+		Y: sp.Sum.Seconds(),
 	}
 }
 
 type TeamPlot struct {
-	Name  string
-	Plots []SinglePlot
-	Index int
-	Sum   int
-	Color color.Color
+	Name          string
+	ReferencePace hakone.Time
+	Plots         []SinglePlot
+	Index         int
+	Sum           hakone.Time
+	Color         color.Color
 }
 
-func NewTeamPlot(name string, red, green, blue uint8) *TeamPlot {
+func NewTeamPlot(edition hakone.Edition, name string, red, green, blue uint8) *TeamPlot {
 	tp := TeamPlot{
-		Name:  name,
-		Index: 1,
-		Sum:   0,
-		Plots: make([]SinglePlot, 11),
-		Color: color.RGBA{R: red, G: green, B: blue, A: 255},
+		Name:          name,
+		ReferencePace: edition.ReferencePace,
+		Index:         1,
+		Sum:           0,
+		Plots:         make([]SinglePlot, edition.ScoredRunnersPerTeam+1),
+		Color:         color.RGBA{R: red, G: green, B: blue, A: 255},
 	}
 	tp.Plots[0] = SinglePlot{
 		Index: 0,
@@ -127,10 +141,8 @@ func NewTeamPlot(name string, red, green, blue uint8) *TeamPlot {
 	return &tp
 }
 
-var even3Minutes30Seconds = (10*60*60 + 57*60) / 10
-
 func (tp *TeamPlot) Append(record hakone.Record) {
-	tp.Sum += even3Minutes30Seconds - int(record.FinishTime)
+	tp.Sum = tp.Sum.Add(tp.ReferencePace.Sub(record.FinishTime))
 	tp.Plots[tp.Index] = SinglePlot{
 		Index: tp.Index,
 		Sum:   tp.Sum,
@@ -1,17 +1,23 @@
 package main
 
 import (
-	"bytes"
 	"encoding/json"
+	"flag"
 	"github.com/ledongthuc/pdf"
 	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/mike-neck/go-hakone-qualification/hakone/repository"
+	"github.com/mike-neck/go-hakone-qualification/parser"
 	"log"
 	"os"
-	"strings"
 )
 
 func main() {
-	closeable, reader, err := pdf.Open("data/hakone-96-teams.pdf")
+	editionNumber := flag.Int("edition", hakone.DefaultEditionNumber, "edition number of the race")
+	compare := flag.Bool("compare", false, "compare entrants with records of the personal result jsonl file")
+	flag.Parse()
+	edition := hakone.NewEdition(*editionNumber)
+
+	closeable, reader, err := pdf.Open(edition.TeamsPdfFile())
 	if err != nil {
 		log.Fatalln("failed to open data file", err)
 	}
@@ -19,121 +25,82 @@ func main() {
 		_ = closeable.Close()
 	}()
 
-	page := reader.Page(1)
-	content := page.Content()
-	texts := content.Text
-
-	if len(texts) <= 0 {
-		log.Fatalln("no data")
+	registry, err := repository.LoadTeamRegistry(hakone.TeamRegistryJsonlFile)
+	if err != nil {
+		log.Fatalln("failed to load teams of past editions", err)
 	}
-
-	firstYAxis := texts[0].Y
-	operator := NewOperator(firstYAxis)
-
-	for _, text := range texts {
-		operator.Operate(text)
+	rosterParser := parser.NewRosterParser(edition, registry)
+	for pageNum := 1; pageNum <= reader.NumPage(); pageNum++ {
+		content := reader.Page(pageNum).Content()
+		if err := rosterParser.ParsePage(content.Text); err != nil {
+			log.Fatalln("failed to parse page", pageNum, err)
+		}
 	}
-	names := operator.Names()
-	teams := make([]hakone.Team, len(names))
-	for index, name := range names {
-		teams[index] = hakone.Team{Id: index + 1, Name: name}
+	if len(rosterParser.Rosters) == 0 {
+		log.Fatalln("no data")
 	}
-
-	jsonFile, err := os.Create("data/hakone-96-teams.jsonl")
-	if err != nil {
-		log.Fatalln("failed to create result json file", err)
+	if err := repository.WriteTeamRegistry(hakone.TeamRegistryJsonlFile, registry); err != nil {
+		log.Fatalln("failed to write teams of every edition", err)
 	}
-	defer func() {
-		_ = jsonFile.Close()
-	}()
 
-	encoder := json.NewEncoder(jsonFile)
 	stdout := json.NewEncoder(os.Stdout)
-	for _, t := range teams {
-		_ = encoder.Encode(t)
-		_ = stdout.Encode(t)
-	}
-}
-
-var invalidBytes = []byte{239, 191, 189}
-
-func IsInvalidText(text pdf.Text) bool {
-	return !IsValidText(text)
-}
-
-func IsValidText(text pdf.Text) bool {
-	if text.S == "\n" {
-		return false
+	err = writeJsonl(edition.TeamsJsonlFile(), len(rosterParser.Rosters), func(encoder *json.Encoder, index int) error {
+		team := rosterParser.Rosters[index].Team
+		_ = stdout.Encode(team)
+		return encoder.Encode(team)
+	})
+	if err != nil {
+		log.Fatalln("failed to write teams", err)
 	}
-	bs := []byte(text.S)
-	if len(bs) != 3 {
-		return true
+	err = writeJsonl(edition.RostersJsonlFile(), len(rosterParser.Rosters), func(encoder *json.Encoder, index int) error {
+		return encoder.Encode(rosterParser.Rosters[index])
+	})
+	if err != nil {
+		log.Fatalln("failed to write rosters", err)
 	}
-	for i := 0; i < 3; i++ {
-		if bs[i] != invalidBytes[i] {
-			return true
+
+	if *compare {
+		repo, err := repository.LoadEdition(edition)
+		if err != nil {
+			log.Fatalln("failed to read records", err)
+		}
+		records := repo.ListAllRunners()
+		for _, roster := range rosterParser.Rosters {
+			absent, unregistered := roster.Compare(recordsOf(registry, roster.Team, records))
+			for _, entrant := range absent {
+				log.Println("info", roster.Team.Name, "no record", entrant.Runner)
+			}
+			for _, record := range unregistered {
+				log.Println("warning", roster.Team.Name, "not in roster", record.Runner)
+			}
 		}
 	}
-	return false
-}
-
-type Operator interface {
-	Operate(text pdf.Text)
-	IsNewLine(text pdf.Text) bool
-	Print()
-	NewLineStart(text pdf.Text)
-	Append(text pdf.Text)
-	Names() []string
-}
-
-type BufferOperator struct {
-	names  []string
-	yAxis  float64
-	buffer *bytes.Buffer
 }
 
-func (bo *BufferOperator) Operate(text pdf.Text) {
-	if IsInvalidText(text) {
-		return
+func writeJsonl(path string, size int, encode func(encoder *json.Encoder, index int) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
 	}
-	if bo.IsNewLine(text) {
-		bo.Print()
-		bo.NewLineStart(text)
-	} else {
-		bo.Append(text)
+	defer func() {
+		_ = file.Close()
+	}()
+	encoder := json.NewEncoder(file)
+	for index := 0; index < size; index++ {
+		if err := encode(encoder, index); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (bo *BufferOperator) IsNewLine(text pdf.Text) bool {
-	return bo.yAxis != text.Y
-}
-
-func (bo *BufferOperator) Print() {
-	text := bo.buffer.String()
-	if strings.Contains(text, "大学") &&
-		!strings.Contains(text, "第96回") &&
-		!strings.Contains(text, "人数") {
-		bo.names = append(bo.names, text)
+// recordsOf picks records of the team, the result sheet has short names like "東洋大" for "東洋大学".
+func recordsOf(registry *hakone.TeamRegistry, team hakone.Team, records []hakone.Record) []hakone.Record {
+	result := make([]hakone.Record, 0)
+	for _, record := range records {
+		if t, ok := registry.Resolve(string(record.Team)); ok && t.Id == team.Id {
+			result = append(result, record)
+		}
 	}
-}
-
-func (bo *BufferOperator) NewLineStart(text pdf.Text) {
-	var buffer bytes.Buffer
-	buffer.WriteString(text.S)
-	bo.yAxis = text.Y
-	bo.buffer = &buffer
-}
-
-func (bo *BufferOperator) Append(text pdf.Text) {
-	bo.buffer.WriteString(text.S)
-}
-
-func (bo *BufferOperator) Names() []string {
-	return bo.names
-}
-
-func NewOperator(yAxis float64) Operator {
-	var buffer bytes.Buffer
-	operator := BufferOperator{yAxis: yAxis, buffer: &buffer, names: make([]string, 0)}
-	return &operator
+	return result
 }
@@ -9,3 +9,5 @@ require (
 	github.com/stretchr/testify v1.4.0
 	gonum.org/v1/plot v0.0.0-20191004082913-159cd04f920c
 )
+
+replace github.com/mike-neck/go-hakone-qualification/hakone => ./hakone
@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"math"
+)
+
+// Segment is a section of the course between two timing points in km.
+type Segment struct {
+	From float64
+	To   float64
+	Time hakone.Time
+}
+
+func (s Segment) Distance() float64 {
+	return s.To - s.From
+}
+
+// Pace returns the time per km of the segment.
+func (s Segment) Pace() hakone.Time {
+	return s.Time.Pace(s.Distance())
+}
+
+// Checkpoints are the timing points of the course, the last segment from 20km to the finish is 1.0975km.
+var Checkpoints = []float64{0, 5, 10, 15, 20, hakone.HalfMarathon}
+
+// halfway is the nearest timing point to the half of the course.
+const halfway = 10
+
+// Splits has every segment of a runner derived from the pass times.
+type Splits struct {
+	Record   hakone.Record
+	Segments []Segment
+}
+
+// NewSplits returns false when the record lacks any pass time or the finish time.
+func NewSplits(record hakone.Record) (Splits, bool) {
+	passes := []hakone.Time{
+		0,
+		record.TimeOf5km,
+		record.TimeOf10km,
+		record.TimeOf15km,
+		record.TimeOf20km,
+		record.FinishTime,
+	}
+	segments := make([]Segment, 0, len(Checkpoints)-1)
+	for i := 1; i < len(passes); i++ {
+		if passes[i] <= passes[i-1] {
+			return Splits{}, false
+		}
+		segments = append(segments, Segment{
+			From: Checkpoints[i-1],
+			To:   Checkpoints[i],
+			Time: passes[i].Sub(passes[i-1]),
+		})
+	}
+	return Splits{Record: record, Segments: segments}, true
+}
+
+// Paces returns the pace of each segment.
+func (s Splits) Paces() []hakone.Time {
+	paces := make([]hakone.Time, len(s.Segments))
+	for i, segment := range s.Segments {
+		paces[i] = segment.Pace()
+	}
+	return paces
+}
+
+// Pace returns the average pace of the whole course.
+func (s Splits) Pace() hakone.Time {
+	return s.Record.FinishTime.Pace(hakone.HalfMarathon)
+}
+
+// FirstHalf is the segment from the start to 10km.
+func (s Splits) FirstHalf() Segment {
+	return Segment{From: 0, To: halfway, Time: s.Record.TimeOf10km}
+}
+
+// SecondHalf is the segment from 10km to the finish.
+func (s Splits) SecondHalf() Segment {
+	return Segment{From: halfway, To: hakone.HalfMarathon, Time: s.Record.FinishTime.Sub(s.Record.TimeOf10km)}
+}
+
+// FadeRatio is the pace of the second half divided by the pace of the first half, more than 1 means the runner slowed down.
+func (s Splits) FadeRatio() float64 {
+	return float64(s.SecondHalf().Pace()) / float64(s.FirstHalf().Pace())
+}
+
+// IsNegativeSplit returns true when the second half is faster than the first half.
+func (s Splits) IsNegativeSplit() bool {
+	return s.SecondHalf().Pace().Before(s.FirstHalf().Pace())
+}
+
+// EvenPaceDeviation is the standard deviation of the segment paces from the average pace weighted by the distance.
+func (s Splits) EvenPaceDeviation() hakone.Time {
+	pace := float64(s.Pace())
+	sum := 0.0
+	for _, segment := range s.Segments {
+		diff := float64(segment.Pace()) - pace
+		sum += diff * diff * segment.Distance()
+	}
+	return hakone.Time(math.Round(math.Sqrt(sum / hakone.HalfMarathon)))
+}
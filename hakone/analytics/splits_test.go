@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newRecord(team string, pass5, pass10, pass15, pass20, finish int) hakone.Record {
+	return hakone.Record{
+		Team:       hakone.TeamName(team),
+		TimeOf5km:  hakone.Seconds(pass5),
+		TimeOf10km: hakone.Seconds(pass10),
+		TimeOf15km: hakone.Seconds(pass15),
+		TimeOf20km: hakone.Seconds(pass20),
+		FinishTime: hakone.Seconds(finish),
+	}
+}
+
+var (
+	fading   = newRecord("東洋大", 900, 1810, 2730, 3660, 3860)
+	negative = newRecord("東洋大", 930, 1860, 2760, 3650, 3845)
+)
+
+func TestNewSplits(t *testing.T) {
+	splits, ok := NewSplits(fading)
+	assert.True(t, ok)
+	assert.Len(t, splits.Segments, 5)
+	assert.Equal(t, Segment{From: 0, To: 5, Time: hakone.Seconds(900)}, splits.Segments[0])
+	assert.Equal(t, Segment{From: 20, To: hakone.HalfMarathon, Time: hakone.Seconds(200)}, splits.Segments[4])
+	assert.Equal(t, []hakone.Time{
+		hakone.Seconds(180),
+		hakone.Seconds(182),
+		hakone.Seconds(184),
+		hakone.Seconds(186),
+		hakone.Time(182232),
+	}, splits.Paces())
+}
+
+func TestNewSplits_Incomplete(t *testing.T) {
+	record := fading
+	record.TimeOf15km = 0
+	_, ok := NewSplits(record)
+	assert.False(t, ok)
+
+	record = fading
+	record.FinishTime = 0
+	_, ok = NewSplits(record)
+	assert.False(t, ok)
+}
+
+func TestSplits_FadeRatio(t *testing.T) {
+	splits, _ := NewSplits(fading)
+	assert.False(t, splits.IsNegativeSplit())
+	assert.InDelta(t, 1.0206, splits.FadeRatio(), 0.0001)
+
+	splits, _ = NewSplits(negative)
+	assert.True(t, splits.IsNegativeSplit())
+	assert.True(t, splits.FadeRatio() < 1)
+}
+
+func TestSplits_EvenPaceDeviation(t *testing.T) {
+	even := newRecord("東洋大", 900, 1800, 2700, 3600, 3798)
+	splits, _ := NewSplits(even)
+	assert.True(t, splits.EvenPaceDeviation() < hakone.Seconds(1))
+
+	splits, _ = NewSplits(fading)
+	assert.Equal(t, hakone.Time(2184), splits.EvenPaceDeviation())
+}
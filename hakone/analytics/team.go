@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"sort"
+)
+
+// TeamSplits aggregates the splits of the runners of a team.
+type TeamSplits struct {
+	Team    hakone.TeamName
+	Runners []Splits
+}
+
+// ByTeam derives the splits of every scored record grouped by team, as team totals count only scored runners.
+// Open entries, disqualified runners and records without complete splits are skipped.
+func ByTeam(records []hakone.Record) map[hakone.TeamName]*TeamSplits {
+	teams := make(map[hakone.TeamName]*TeamSplits)
+	for _, record := range records {
+		if !record.IsScored() {
+			continue
+		}
+		splits, ok := NewSplits(record)
+		if !ok {
+			continue
+		}
+		team, ok := teams[record.Team]
+		if !ok {
+			team = &TeamSplits{Team: record.Team}
+			teams[record.Team] = team
+		}
+		team.Runners = append(team.Runners, splits)
+	}
+	for _, team := range teams {
+		sort.SliceStable(team.Runners, func(i, j int) bool {
+			return team.Runners[i].Record.FinishTime < team.Runners[j].Record.FinishTime
+		})
+	}
+	return teams
+}
+
+// Top returns the splits of the fastest runners up to the size.
+func (ts *TeamSplits) Top(size int) *TeamSplits {
+	if len(ts.Runners) <= size {
+		return ts
+	}
+	return &TeamSplits{Team: ts.Team, Runners: ts.Runners[:size]}
+}
+
+// AveragePaces returns the average pace of each segment.
+func (ts *TeamSplits) AveragePaces() []hakone.Time {
+	averages := make([]hakone.Time, len(Checkpoints)-1)
+	if len(ts.Runners) == 0 {
+		return averages
+	}
+	for i := range averages {
+		paces := make([]hakone.Time, len(ts.Runners))
+		for j, runner := range ts.Runners {
+			paces[j] = runner.Segments[i].Pace()
+		}
+		averages[i] = hakone.Average(paces)
+	}
+	return averages
+}
+
+func (ts *TeamSplits) AverageFadeRatio() float64 {
+	if len(ts.Runners) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, runner := range ts.Runners {
+		sum += runner.FadeRatio()
+	}
+	return sum / float64(len(ts.Runners))
+}
+
+func (ts *TeamSplits) NegativeSplitCount() int {
+	count := 0
+	for _, runner := range ts.Runners {
+		if runner.IsNegativeSplit() {
+			count++
+		}
+	}
+	return count
+}
+
+func (ts *TeamSplits) AverageEvenPaceDeviation() hakone.Time {
+	deviations := make([]hakone.Time, len(ts.Runners))
+	for i, runner := range ts.Runners {
+		deviations[i] = runner.EvenPaceDeviation()
+	}
+	return hakone.Average(deviations)
+}
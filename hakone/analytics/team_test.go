@@ -0,0 +1,44 @@
+package analytics
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestByTeam(t *testing.T) {
+	incomplete := newRecord("東洋大", 900, 0, 2730, 3660, 3860)
+	other := newRecord("東海大", 900, 1800, 2700, 3600, 3800)
+	openEntry := newRecord("東洋大", 880, 1760, 2640, 3520, 3720)
+	openEntry.Status = hakone.StatusOpen
+	disqualified := newRecord("東海大", 880, 1760, 2640, 3520, 3720)
+	disqualified.Status = hakone.StatusDQ
+	teams := ByTeam([]hakone.Record{fading, other, negative, incomplete, openEntry, disqualified})
+
+	assert.Len(t, teams, 2)
+	toyo := teams["東洋大"]
+	assert.Len(t, toyo.Runners, 2)
+	assert.Equal(t, negative, toyo.Runners[0].Record)
+	assert.Equal(t, 1, toyo.NegativeSplitCount())
+	assert.Equal(t, 0, teams["東海大"].NegativeSplitCount())
+	assert.Len(t, teams["東海大"].Runners, 1)
+	assert.Len(t, toyo.Top(1).Runners, 1)
+}
+
+func TestTeamSplits_AveragePaces(t *testing.T) {
+	teams := ByTeam([]hakone.Record{fading, negative})
+	paces := teams["東洋大"].AveragePaces()
+	assert.Equal(t, hakone.Seconds(183), paces[0])
+	assert.Equal(t, hakone.Seconds(184), paces[1])
+
+	fadingSplits, _ := NewSplits(fading)
+	negativeSplits, _ := NewSplits(negative)
+	assert.InDelta(t, (fadingSplits.FadeRatio()+negativeSplits.FadeRatio())/2, teams["東洋大"].AverageFadeRatio(), 0.000001)
+}
+
+func TestTeamSplits_Empty(t *testing.T) {
+	team := &TeamSplits{Team: "東洋大"}
+	assert.Equal(t, 0.0, team.AverageFadeRatio())
+	assert.Equal(t, hakone.Time(0), team.AverageEvenPaceDeviation())
+	assert.Equal(t, make([]hakone.Time, 5), team.AveragePaces())
+}
@@ -0,0 +1,25 @@
+package hakone
+
+// TeamBonus is a deduction from the team total given by Kanto Intercollegiate points.
+type TeamBonus struct {
+	TeamId    int  `json:"team_id"`
+	Deduction Time `json:"deduction"`
+}
+
+type BonusTable map[int]Time
+
+func NewBonusTable(bonuses []TeamBonus) BonusTable {
+	table := make(BonusTable)
+	for _, bonus := range bonuses {
+		table[bonus.TeamId] += bonus.Deduction
+	}
+	return table
+}
+
+// DeductionOf returns the bonus time of the team, or zero when the team has no bonus.
+func (bt BonusTable) DeductionOf(team Team) Time {
+	if bt == nil {
+		return 0
+	}
+	return bt[team.Id]
+}
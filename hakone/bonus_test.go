@@ -0,0 +1,22 @@
+package hakone
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBonusTable_DeductionOf(t *testing.T) {
+	table := NewBonusTable([]TeamBonus{
+		{TeamId: 1, Deduction: Seconds(60)},
+		{TeamId: 2, Deduction: Seconds(30)},
+	})
+
+	assert.Equal(t, Seconds(60), table.DeductionOf(Team{Id: 1, Name: "東海大"}))
+	assert.Equal(t, Seconds(30), table.DeductionOf(Team{Id: 2, Name: "東洋大"}))
+	assert.Equal(t, Time(0), table.DeductionOf(Team{Id: 3, Name: "早稲田大"}))
+}
+
+func TestBonusTable_DeductionOf_Nil(t *testing.T) {
+	var table BonusTable
+	assert.Equal(t, Time(0), table.DeductionOf(Team{Id: 1, Name: "東海大"}))
+}
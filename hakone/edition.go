@@ -0,0 +1,81 @@
+package hakone
+
+import "fmt"
+
+const (
+	DefaultEditionNumber        = 96
+	DefaultQualifyingSlots      = 10
+	DefaultScoredRunnersPerTeam = 10
+	DefaultMaxEntrantsPerTeam   = 12
+	// DefaultReferencePace is a finish time per runner which makes the team total 10:57:00.
+	DefaultReferencePace = (10*Hour + 57*Minute) / DefaultScoredRunnersPerTeam
+)
+
+// Edition is a configuration of the qualification race of each year.
+type Edition struct {
+	Number               int  `json:"edition"`
+	QualifyingSlots      int  `json:"qualifying_slots"`
+	ScoredRunnersPerTeam int  `json:"scored_runners_per_team"`
+	MaxEntrantsPerTeam   int  `json:"max_entrants_per_team"`
+	ReferencePace        Time `json:"reference_pace"`
+}
+
+var knownEditions = map[int]Edition{
+	95:  {Number: 95, QualifyingSlots: 11},
+	96:  {Number: 96, QualifyingSlots: 10},
+	100: {Number: 100, QualifyingSlots: 13},
+}
+
+// NewEdition returns the configuration of the edition, unknown editions have default values.
+func NewEdition(number int) Edition {
+	edition, ok := knownEditions[number]
+	if !ok {
+		edition = Edition{Number: number}
+	}
+	return edition.WithDefaults()
+}
+
+// WithDefaults fills zero-value fields with default values.
+func (e Edition) WithDefaults() Edition {
+	if e.Number <= 0 {
+		e.Number = DefaultEditionNumber
+	}
+	if e.QualifyingSlots <= 0 {
+		e.QualifyingSlots = DefaultQualifyingSlots
+	}
+	if e.ScoredRunnersPerTeam <= 0 {
+		e.ScoredRunnersPerTeam = DefaultScoredRunnersPerTeam
+	}
+	if e.MaxEntrantsPerTeam <= 0 {
+		e.MaxEntrantsPerTeam = DefaultMaxEntrantsPerTeam
+	}
+	if e.ReferencePace <= 0 {
+		e.ReferencePace = DefaultReferencePace
+	}
+	return e
+}
+
+// ReferenceTotal is a team total time when every scored runner finishes at the reference pace.
+func (e Edition) ReferenceTotal() Time {
+	return e.ReferencePace * Time(e.ScoredRunnersPerTeam)
+}
+
+func (e Edition) PersonalPdfFile() string {
+	return fmt.Sprintf("data/hakone-%d-personal.pdf", e.Number)
+}
+
+func (e Edition) PersonalJsonlFile() string {
+	return fmt.Sprintf("data/hakone-%d-personal.jsonl", e.Number)
+}
+
+func (e Edition) TeamsPdfFile() string {
+	return fmt.Sprintf("data/hakone-%d-teams.pdf", e.Number)
+}
+
+func (e Edition) TeamsJsonlFile() string {
+	return fmt.Sprintf("data/hakone-%d-teams.jsonl", e.Number)
+}
+
+func (e Edition) RostersJsonlFile() string {
+	return fmt.Sprintf("data/hakone-%d-rosters.jsonl", e.Number)
+}
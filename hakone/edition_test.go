@@ -0,0 +1,33 @@
+package hakone
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewEdition_Known(t *testing.T) {
+	edition := NewEdition(95)
+	assert.Equal(t, 95, edition.Number)
+	assert.Equal(t, 11, edition.QualifyingSlots)
+	assert.Equal(t, DefaultScoredRunnersPerTeam, edition.ScoredRunnersPerTeam)
+	assert.Equal(t, DefaultMaxEntrantsPerTeam, edition.MaxEntrantsPerTeam)
+}
+
+func TestNewEdition_Unknown(t *testing.T) {
+	edition := NewEdition(101)
+	assert.Equal(t, 101, edition.Number)
+	assert.Equal(t, DefaultQualifyingSlots, edition.QualifyingSlots)
+	assert.Equal(t, "data/hakone-101-personal.pdf", edition.PersonalPdfFile())
+}
+
+func TestEdition_ReferenceTotal(t *testing.T) {
+	edition := NewEdition(96)
+	assert.Equal(t, 10*Hour+57*Minute, edition.ReferenceTotal())
+}
+
+func TestEdition_WithDefaults(t *testing.T) {
+	edition := Edition{QualifyingSlots: 2}.WithDefaults()
+	assert.Equal(t, DefaultEditionNumber, edition.Number)
+	assert.Equal(t, 2, edition.QualifyingSlots)
+	assert.Equal(t, DefaultReferencePace, edition.ReferencePace)
+}
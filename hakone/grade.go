@@ -0,0 +1,89 @@
+package hakone
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	MinGrade = 1
+	// MaxGrade allows students of 6-year courses.
+	MaxGrade = 6
+)
+
+// Grade is the academic year of the runner, zero means unknown.
+// It is marshaled into a number in JSON and into the sheet form like "(3)" as text.
+type Grade int
+
+// NewGrade parses the grade written as "(3)" or "3".
+func NewGrade(g string) (Grade, error) {
+	value := strings.TrimSpace(g)
+	value = strings.TrimPrefix(value, "(")
+	value = strings.TrimSuffix(value, ")")
+	year, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("invalid grade: %s", g))
+	}
+	grade := Grade(year)
+	if !grade.IsValid() {
+		return 0, errors.New(fmt.Sprintf("grade out of range: %s", g))
+	}
+	return grade, nil
+}
+
+func (g Grade) IsValid() bool {
+	return MinGrade <= g && g <= MaxGrade
+}
+
+func (g Grade) Year() int {
+	return int(g)
+}
+
+func (g Grade) String() string {
+	if g == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%d)", int(g))
+}
+
+func (g Grade) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+func (g *Grade) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*g = 0
+		return nil
+	}
+	grade, err := NewGrade(string(text))
+	if err != nil {
+		return err
+	}
+	*g = grade
+	return nil
+}
+
+func (g Grade) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(g))
+}
+
+// UnmarshalJSON accepts both a number and the sheet form string written before Grade became a number.
+func (g *Grade) UnmarshalJSON(bytes []byte) error {
+	var year int
+	if err := json.Unmarshal(bytes, &year); err == nil {
+		grade := Grade(year)
+		if grade != 0 && !grade.IsValid() {
+			return errors.New(fmt.Sprintf("grade out of range: %d", year))
+		}
+		*g = grade
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(bytes, &text); err != nil {
+		return errors.Wrapf(err, "grade should be number or string: %s", string(bytes))
+	}
+	return g.UnmarshalText([]byte(text))
+}
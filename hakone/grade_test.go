@@ -0,0 +1,55 @@
+package hakone
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewGrade(t *testing.T) {
+	grade, err := NewGrade("(3)")
+	assert.Nil(t, err)
+	assert.Equal(t, Grade(3), grade)
+	assert.Equal(t, 3, grade.Year())
+	assert.Equal(t, "(3)", grade.String())
+}
+
+func TestNewGrade_WithoutParenthesis(t *testing.T) {
+	grade, err := NewGrade("1")
+	assert.Nil(t, err)
+	assert.Equal(t, Grade(1), grade)
+}
+
+func TestNewGrade_Invalid(t *testing.T) {
+	_, err := NewGrade("(0)")
+	assert.NotNil(t, err)
+	_, err = NewGrade("(7)")
+	assert.NotNil(t, err)
+	_, err = NewGrade("(M1)")
+	assert.NotNil(t, err)
+}
+
+func TestGrade_JSON(t *testing.T) {
+	bytes, err := json.Marshal(Grade(2))
+	assert.Nil(t, err)
+	assert.Equal(t, "2", string(bytes))
+
+	var grade Grade
+	assert.Nil(t, json.Unmarshal([]byte(`4`), &grade))
+	assert.Equal(t, Grade(4), grade)
+	assert.Nil(t, json.Unmarshal([]byte(`"(3)"`), &grade))
+	assert.Equal(t, Grade(3), grade)
+	assert.NotNil(t, json.Unmarshal([]byte(`9`), &grade))
+	assert.NotNil(t, json.Unmarshal([]byte(`"(x)"`), &grade))
+}
+
+func TestGrade_Text(t *testing.T) {
+	bytes, err := Grade(1).MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "(1)", string(bytes))
+
+	byGrade := map[Grade]int{1: 3, 2: 1}
+	bytes, err = json.Marshal(byGrade)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"(1)":3,"(2)":1}`, string(bytes))
+}
@@ -0,0 +1,150 @@
+package hakone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// halfWidthKatakana maps half-width katakana from U+FF66 to full-width katakana.
+var halfWidthKatakana = []rune("ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン")
+
+// FoldWidth converts full-width letters into half-width and half-width katakana into full-width.
+func FoldWidth(s string) string {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 0xFF01 && r <= 0xFF5E:
+			runes = append(runes, r-0xFEE0)
+		case r == 0x3000:
+			runes = append(runes, ' ')
+		case r >= 0xFF66 && r <= 0xFF9D:
+			runes = append(runes, halfWidthKatakana[r-0xFF66])
+		case (r == 0xFF9E || r == 0xFF9F) && len(runes) > 0:
+			runes[len(runes)-1] = voice(runes[len(runes)-1], r == 0xFF9F)
+		default:
+			runes = append(runes, r)
+		}
+	}
+	return string(runes)
+}
+
+// voice adds a voiced or semi-voiced mark to the katakana.
+func voice(r rune, semi bool) rune {
+	switch {
+	case r == 'ウ' && !semi:
+		return 'ヴ'
+	case r >= 'ハ' && r <= 'ホ' && (r-'ハ')%3 == 0:
+		if semi {
+			return r + 2
+		}
+		return r + 1
+	case !semi && r >= 'カ' && r <= 'ト' && r != 'ッ':
+		return r + 1
+	}
+	return r
+}
+
+// ToHiragana converts katakana into hiragana.
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+var romaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'を': "o", 'ん': "n",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゔ': "vu",
+}
+
+var smallY = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// Romanize converts kana into the Hepburn romanization without macrons, other characters are left as they are.
+func Romanize(s string) string {
+	runes := []rune(ToHiragana(s))
+	var builder strings.Builder
+	double := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == 'っ' {
+			double = true
+			continue
+		}
+		syllable, ok := romaji[r]
+		if !ok {
+			builder.WriteRune(r)
+			double = false
+			continue
+		}
+		if i+1 < len(runes) {
+			if vowel, ok := smallY[runes[i+1]]; ok && strings.HasSuffix(syllable, "i") && len(syllable) > 1 {
+				base := strings.TrimSuffix(syllable, "i")
+				if base != "sh" && base != "ch" && base != "j" {
+					base += "y"
+				}
+				syllable = base + vowel
+				i++
+			}
+		}
+		if double && syllable[0] != 'a' && syllable[0] != 'i' && syllable[0] != 'u' && syllable[0] != 'e' && syllable[0] != 'o' {
+			if strings.HasPrefix(syllable, "ch") {
+				builder.WriteString("t")
+			} else {
+				builder.WriteByte(syllable[0])
+			}
+		}
+		double = false
+		builder.WriteString(syllable)
+	}
+	return builder.String()
+}
+
+// NormalizeRomaji lowers case, removes non letters and shortens long vowels, so that "touyou" and "Toyo" are the same.
+func NormalizeRomaji(s string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(FoldWidth(s)) {
+		if r < unicode.MaxASCII && unicode.IsLetter(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return strings.NewReplacer("ou", "o", "oo", "o", "uu", "u").Replace(builder.String())
+}
+
+// IsRomaji returns true when the text has only ascii letters and spaces.
+func IsRomaji(s string) bool {
+	folded := FoldWidth(s)
+	for _, r := range folded {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsSpace(r) || r == '.') {
+			return false
+		}
+	}
+	return strings.TrimSpace(folded) != ""
+}
+
+// NormalizeRunnerName removes spaces between family name and given name which appear only in some sheets, folds the
+// width, lowers case and converts katakana into hiragana.
+func NormalizeRunnerName(name string) string {
+	var builder strings.Builder
+	for _, r := range ToHiragana(strings.ToLower(FoldWidth(name))) {
+		if !unicode.IsSpace(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
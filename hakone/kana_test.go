@@ -0,0 +1,47 @@
+package hakone
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFoldWidth(t *testing.T) {
+	assert.Equal(t, "Toyo 1", FoldWidth("Ｔｏｙｏ　１"))
+	assert.Equal(t, "トウヨウ", FoldWidth("ﾄｳﾖｳ"))
+	assert.Equal(t, "ダイガク", FoldWidth("ﾀﾞｲｶﾞｸ"))
+	assert.Equal(t, "パ", FoldWidth("ﾊﾟ"))
+}
+
+func TestToHiragana(t *testing.T) {
+	assert.Equal(t, "とうようだい", ToHiragana("トウヨウダイ"))
+	assert.Equal(t, "東洋", ToHiragana("東洋"))
+}
+
+func TestRomanize(t *testing.T) {
+	assert.Equal(t, "touyou", Romanize("とうよう"))
+	assert.Equal(t, "toukyoukokusai", Romanize("トウキョウコクサイ"))
+	assert.Equal(t, "chuuou", Romanize("ちゅうおう"))
+	assert.Equal(t, "nippon", Romanize("にっぽん"))
+	assert.Equal(t, "takushoku", Romanize("たくしょく"))
+	assert.Equal(t, "jobu", Romanize("じょぶ"))
+	assert.Equal(t, "matcha", Romanize("まっちゃ"))
+}
+
+func TestNormalizeRomaji(t *testing.T) {
+	assert.Equal(t, "toyo", NormalizeRomaji("touyou"))
+	assert.Equal(t, "toyo", NormalizeRomaji("Ｔｏｙｏ"))
+	assert.Equal(t, "tokyokokusai", NormalizeRomaji("Tokyo Kokusai"))
+}
+
+func TestIsRomaji(t *testing.T) {
+	assert.True(t, IsRomaji("Toyo Univ"))
+	assert.True(t, IsRomaji("ｔｏｙｏ"))
+	assert.False(t, IsRomaji("東洋"))
+	assert.False(t, IsRomaji(" "))
+}
+
+func TestNormalizeRunnerName(t *testing.T) {
+	assert.Equal(t, "山田太郎", NormalizeRunnerName("山田　太郎"))
+	assert.Equal(t, "むせんび", NormalizeRunnerName("ﾑｾﾝﾋﾞ"))
+	assert.Equal(t, "mwangi", NormalizeRunnerName("ＭＷＡＮＧＩ"))
+}
@@ -1,6 +1,7 @@
 package hakone
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	"strconv"
@@ -8,15 +9,22 @@ import (
 )
 
 type Runner string
-type Grade string
 type TeamName string
 type Note string
 
+// Time is a duration in milliseconds, so that fractions of the official timing are kept.
 type Time int
 
+// Record has the official place printed on the sheet and Order, the rank computed from finish times.
+// Edition is the number of the edition, zero in files written before it was introduced.
 type Record struct {
+	Edition           int      `json:"edition,omitempty"`
 	Order             int      `json:"order"`
+	Place             int      `json:"place"`
+	Bib               int      `json:"bib"`
 	Runner            Runner   `json:"runner"`
+	RomanizedName     string   `json:"romanized_name"`
+	Nationality       string   `json:"nationality"`
 	Grade             Grade    `json:"grade"`
 	Team              TeamName `json:"team"`
 	TimeOf5km         Time     `json:"time_of_5_km"`
@@ -24,15 +32,101 @@ type Record struct {
 	TimeOf15km        Time     `json:"time_of_15_km"`
 	TimeOf20km        Time     `json:"time_of_20_km"`
 	FinishTime        Time     `json:"finish_time"`
+	NetTime           Time     `json:"net_time,omitempty"`
 	RapFrom5kmTo10km  Time     `json:"rap_5_to_10"`
 	RapFrom10kmTo15km Time     `json:"rap_10_to_15"`
 	RapFrom15kmTo20km Time     `json:"rap_15_to_20"`
 	Note              Note
+	Status            Status `json:"status"`
 }
 
-func (t Time) plus(d int) Time {
-	result := int(t) + d
-	return Time(result)
+// IsScored returns true when the record counts for the team total.
+func (r Record) IsScored() bool {
+	return r.Status.IsScored() && r.FinishTime > 0
+}
+
+// TimeBasis decides which finish time is used, the qualification is scored on the gun time.
+type TimeBasis int
+
+const (
+	GunTime TimeBasis = iota
+	NetTime
+)
+
+// FinishTimeOf returns the finish time of the basis, the gun time is used when the net time is not recorded.
+func (r Record) FinishTimeOf(basis TimeBasis) Time {
+	if basis == NetTime && r.NetTime > 0 {
+		return r.NetTime
+	}
+	return r.FinishTime
+}
+
+// recordFields has the fields of Record without its json methods.
+type recordFields Record
+
+// recordJSON is version 2 of the json of Record. Times are whole seconds as version 1, which has integer seconds
+// only, and times with fraction have their milliseconds in the *_ms fields too, so readers of version 1 still read
+// the records.
+type recordJSON struct {
+	recordFields
+	TimeOf5kmMs         int `json:"time_of_5_km_ms,omitempty"`
+	TimeOf10kmMs        int `json:"time_of_10_km_ms,omitempty"`
+	TimeOf15kmMs        int `json:"time_of_15_km_ms,omitempty"`
+	TimeOf20kmMs        int `json:"time_of_20_km_ms,omitempty"`
+	FinishTimeMs        int `json:"finish_time_ms,omitempty"`
+	NetTimeMs           int `json:"net_time_ms,omitempty"`
+	RapFrom5kmTo10kmMs  int `json:"rap_5_to_10_ms,omitempty"`
+	RapFrom10kmTo15kmMs int `json:"rap_10_to_15_ms,omitempty"`
+	RapFrom15kmTo20kmMs int `json:"rap_15_to_20_ms,omitempty"`
+}
+
+type timeMillis struct {
+	time   *Time
+	millis *int
+}
+
+// pairs returns times of the record with their milliseconds fields.
+func (r *recordJSON) pairs() []timeMillis {
+	return []timeMillis{
+		{&r.TimeOf5km, &r.TimeOf5kmMs},
+		{&r.TimeOf10km, &r.TimeOf10kmMs},
+		{&r.TimeOf15km, &r.TimeOf15kmMs},
+		{&r.TimeOf20km, &r.TimeOf20kmMs},
+		{&r.FinishTime, &r.FinishTimeMs},
+		{&r.NetTime, &r.NetTimeMs},
+		{&r.RapFrom5kmTo10km, &r.RapFrom5kmTo10kmMs},
+		{&r.RapFrom10kmTo15km, &r.RapFrom10kmTo15kmMs},
+		{&r.RapFrom15kmTo20km, &r.RapFrom15kmTo20kmMs},
+	}
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	encoded := recordJSON{recordFields: recordFields(r)}
+	for _, pair := range encoded.pairs() {
+		if pair.time.HasFraction() {
+			*pair.millis = int(*pair.time)
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON reads both versions, the *_ms fields take precedence over whole seconds.
+func (r *Record) UnmarshalJSON(bytes []byte) error {
+	var decoded recordJSON
+	if err := json.Unmarshal(bytes, &decoded); err != nil {
+		return err
+	}
+	for _, pair := range decoded.pairs() {
+		if *pair.millis != 0 {
+			*pair.time = Time(*pair.millis)
+		}
+	}
+	*r = Record(decoded.recordFields)
+	return nil
+}
+
+func (t Time) plus(d Time) Time {
+	return t + d
 }
 
 func NewTime(t string) (Time, error) {
@@ -51,7 +145,7 @@ func NewTime(t string) (Time, error) {
 		if err != nil {
 			return 0, err
 		}
-		return minTime.plus(hour * 60 * 60), nil
+		return minTime.plus(Hours(hour)), nil
 	}
 }
 
@@ -69,9 +163,35 @@ func SplitString(original, m, s string) (Time, error) {
 	if err != nil {
 		return 0, errors.New(fmt.Sprintf("invalid number at minute part: %s", original))
 	}
-	sec, err := strconv.Atoi(s)
+	secPart := s
+	fraction := ""
+	if index := strings.Index(s, "."); index >= 0 {
+		secPart = s[:index]
+		fraction = s[index+1:]
+	}
+	sec, err := strconv.Atoi(secPart)
 	if err != nil {
 		return 0, errors.New(fmt.Sprintf("invalid number at second part: %s", original))
 	}
-	return Time(min*60 + sec), nil
+	millis, err := parseFraction(fraction)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("invalid number at fraction part: %s", original))
+	}
+	return Minutes(min).plus(Seconds(sec)).plus(millis), nil
+}
+
+// parseFraction converts digits after the decimal point up to milliseconds, "4" is 400ms and "45" is 450ms.
+func parseFraction(fraction string) (Time, error) {
+	if fraction == "" {
+		return 0, nil
+	}
+	if len(fraction) > 3 {
+		return 0, errors.New(fmt.Sprintf("too many fraction digits: %s", fraction))
+	}
+	padded := fraction + strings.Repeat("0", 3-len(fraction))
+	millis, err := strconv.Atoi(padded)
+	if err != nil || strings.ContainsAny(fraction, "+-") {
+		return 0, errors.New(fmt.Sprintf("invalid fraction: %s", fraction))
+	}
+	return Time(millis), nil
 }
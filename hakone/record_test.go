@@ -8,13 +8,13 @@ import (
 func TestNewTime(t *testing.T) {
 	time, err := NewTime("1:01:23")
 	assert.Nil(t, err)
-	assert.Equal(t, Time(61*60+23), time)
+	assert.Equal(t, Seconds(61*60+23), time)
 }
 
 func TestNewTime_SubHour(t *testing.T) {
 	time, err := NewTime("59:52")
 	assert.Nil(t, err)
-	assert.Equal(t, Time(59*60+52), time)
+	assert.Equal(t, Seconds(59*60+52), time)
 }
 
 func TestNewTime_Failure(t *testing.T) {
@@ -26,3 +26,33 @@ func TestNewTime_InvalidTimeNumber(t *testing.T) {
 	_, err := NewTime("10:DD")
 	assert.NotNil(t, err)
 }
+
+func TestNewTime_Fraction(t *testing.T) {
+	time, err := NewTime("1:01:23.4")
+	assert.Nil(t, err)
+	assert.Equal(t, Seconds(61*60+23)+400*Millisecond, time)
+}
+
+func TestNewTime_FractionHundredths(t *testing.T) {
+	time, err := NewTime("59:52.07")
+	assert.Nil(t, err)
+	assert.Equal(t, Seconds(59*60+52)+70*Millisecond, time)
+}
+
+func TestNewTime_InvalidFraction(t *testing.T) {
+	_, err := NewTime("59:52.1234")
+	assert.NotNil(t, err)
+	_, err = NewTime("59:52.x")
+	assert.NotNil(t, err)
+}
+
+func TestRecord_FinishTimeOf(t *testing.T) {
+	record := Record{FinishTime: Seconds(3723), NetTime: Seconds(3720)}
+	assert.Equal(t, Seconds(3723), record.FinishTimeOf(GunTime))
+	assert.Equal(t, Seconds(3720), record.FinishTimeOf(NetTime))
+}
+
+func TestRecord_FinishTimeOf_WithoutNetTime(t *testing.T) {
+	record := Record{FinishTime: Seconds(3723)}
+	assert.Equal(t, Seconds(3723), record.FinishTimeOf(NetTime))
+}
@@ -0,0 +1,140 @@
+package hakone
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TeamRegistryJsonlFile keeps teams found in any edition, so that teams not in knownTeams have the same id in every
+// edition too.
+const TeamRegistryJsonlFile = "data/hakone-teams.jsonl"
+
+// knownTeams have canonical ids which do not change across editions.
+var knownTeams = []Team{
+	{Id: 1, Name: "東海大学", Reading: "とうかいだいがく", ShortName: "東海大", EnglishName: "Tokai Univ"},
+	{Id: 2, Name: "東洋大学", Reading: "とうようだいがく", ShortName: "東洋大", EnglishName: "Toyo Univ"},
+	{Id: 3, Name: "早稲田大学", Reading: "わせだだいがく", ShortName: "早稲田大", EnglishName: "Waseda Univ", Aliases: []string{"早大"}},
+	{Id: 4, Name: "日本大学", Reading: "にほんだいがく", ShortName: "日本大", EnglishName: "Nihon Univ", Aliases: []string{"日大"}},
+	{Id: 5, Name: "日本体育大学", Reading: "にっぽんたいいくだいがく", ShortName: "日本体育大", EnglishName: "Nippon Sport Science Univ", Aliases: []string{"日体大"}},
+	{Id: 6, Name: "東京国際大学", Reading: "とうきょうこくさいだいがく", ShortName: "東京国際大", EnglishName: "Tokyo Kokusai Univ"},
+	{Id: 7, Name: "山梨学院大学", Reading: "やまなしがくいんだいがく", ShortName: "山梨学院大", EnglishName: "Yamanashi Gakuin Univ"},
+	{Id: 8, Name: "筑波大学", Reading: "つくばだいがく", ShortName: "筑波大", EnglishName: "Tsukuba Univ"},
+	{Id: 9, Name: "麗澤大学", Reading: "れいたくだいがく", ShortName: "麗澤大", EnglishName: "Reitaku Univ"},
+	{Id: 10, Name: "中央大学", Reading: "ちゅうおうだいがく", ShortName: "中央大", EnglishName: "Chuo Univ"},
+	{Id: 11, Name: "上武大学", Reading: "じょうぶだいがく", ShortName: "上武大", EnglishName: "Joubu Univ"},
+	{Id: 12, Name: "駿河台大学", Reading: "するがだいだいがく", ShortName: "駿河台大", EnglishName: "Surugadai Univ"},
+	{Id: 13, Name: "神奈川大学", Reading: "かながわだいがく", ShortName: "神奈川大", EnglishName: "Kanagawa Univ"},
+	{Id: 14, Name: "明治大学", Reading: "めいじだいがく", ShortName: "明治大", EnglishName: "Meiji Univ"},
+	{Id: 15, Name: "創価大学", Reading: "そうかだいがく", ShortName: "創価大", EnglishName: "Soka Univ"},
+	{Id: 16, Name: "国士舘大学", Reading: "こくしかんだいがく", ShortName: "国士舘大", EnglishName: "Kokushikan Univ"},
+	{Id: 17, Name: "城西大学", Reading: "じょうさいだいがく", ShortName: "城西大", EnglishName: "Josai Univ"},
+	{Id: 18, Name: "専修大学", Reading: "せんしゅうだいがく", ShortName: "専修大", EnglishName: "Senshu Univ"},
+	{Id: 19, Name: "大東文化大学", Reading: "だいとうぶんかだいがく", ShortName: "大東文化大", EnglishName: "Daito Bunka Univ", Aliases: []string{"大東大"}},
+	{Id: 20, Name: "拓殖大学", Reading: "たくしょくだいがく", ShortName: "拓殖大", EnglishName: "Takushoku Univ"},
+}
+
+// TeamRegistry resolves names and aliases of teams to the same team.
+type TeamRegistry struct {
+	teams []Team
+	index map[string]int
+}
+
+// NewTeamRegistry returns an error when a name of a team is used by another team.
+func NewTeamRegistry(teams []Team) (*TeamRegistry, error) {
+	registry := TeamRegistry{teams: make([]Team, 0, len(teams)), index: make(map[string]int)}
+	for _, team := range teams {
+		if err := registry.Add(team); err != nil {
+			return nil, err
+		}
+	}
+	return &registry, nil
+}
+
+// DefaultTeamRegistry has known teams.
+func DefaultTeamRegistry() *TeamRegistry {
+	registry, err := NewTeamRegistry(knownTeams)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// Add registers the team, a team with the same id is replaced and names of both resolve to it.
+func (r *TeamRegistry) Add(team Team) error {
+	for _, name := range team.Names() {
+		key := NormalizeTeamName(name)
+		if index, ok := r.index[key]; ok && r.teams[index].Id != team.Id {
+			return errors.New(fmt.Sprintf("name %s of team %d is used by team %d", name, team.Id, r.teams[index].Id))
+		}
+	}
+	index := r.indexOf(team.Id)
+	if index < 0 {
+		r.teams = append(r.teams, team)
+		index = len(r.teams) - 1
+	} else {
+		r.teams[index] = team
+	}
+	for _, name := range team.Names() {
+		r.index[NormalizeTeamName(name)] = index
+	}
+	return nil
+}
+
+func (r *TeamRegistry) indexOf(id int) int {
+	for index, team := range r.teams {
+		if team.Id == id {
+			return index
+		}
+	}
+	return -1
+}
+
+// NextId returns an id which is not used by any team.
+func (r *TeamRegistry) NextId() int {
+	max := 0
+	for _, team := range r.teams {
+		if team.Id > max {
+			max = team.Id
+		}
+	}
+	return max + 1
+}
+
+// Resolve finds the team by any of names and aliases.
+func (r *TeamRegistry) Resolve(name string) (Team, bool) {
+	index, ok := r.index[NormalizeTeamName(name)]
+	if !ok {
+		return Team{}, false
+	}
+	return r.teams[index], true
+}
+
+// Teams returns teams ordered by id.
+func (r *TeamRegistry) Teams() []Team {
+	teams := make([]Team, len(r.teams))
+	copy(teams, r.teams)
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Id < teams[j].Id
+	})
+	return teams
+}
+
+// NormalizeTeamName folds the width of letters, removes spaces, lowers case and unifies "大学" into "大", so that
+// "東洋大学", "東洋　大" and "東洋大" are the same.
+func NormalizeTeamName(name string) string {
+	var builder strings.Builder
+	for _, r := range FoldWidth(name) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	normalized := builder.String()
+	if strings.HasSuffix(normalized, "大学") {
+		normalized = strings.TrimSuffix(normalized, "学")
+	}
+	return normalized
+}
@@ -0,0 +1,61 @@
+package hakone
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeTeamName(t *testing.T) {
+	assert.Equal(t, "東洋大", NormalizeTeamName("東洋大学"))
+	assert.Equal(t, "東洋大", NormalizeTeamName("東洋　大"))
+	assert.Equal(t, "toyouniv", NormalizeTeamName("Ｔｏｙｏ Univ"))
+	assert.Equal(t, "大学院", NormalizeTeamName("大学院"))
+}
+
+func TestTeamRegistry_Resolve(t *testing.T) {
+	registry := DefaultTeamRegistry()
+
+	for _, name := range []string{"早稲田大学", "早稲田大", "早大", "waseda univ", "Ｗａｓｅｄａ Ｕｎｉｖ"} {
+		team, ok := registry.Resolve(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, 3, team.Id, name)
+	}
+
+	_, ok := registry.Resolve("箱根大学")
+	assert.False(t, ok)
+}
+
+func TestTeamRegistry_ResolveWithoutAliases(t *testing.T) {
+	registry, err := NewTeamRegistry([]Team{{Id: 1, Name: "東洋大"}})
+	assert.Nil(t, err)
+
+	team, ok := registry.Resolve("東洋大学")
+
+	assert.True(t, ok)
+	assert.Equal(t, TeamName("東洋大"), team.RecordName())
+}
+
+func TestNewTeamRegistry_Conflict(t *testing.T) {
+	_, err := NewTeamRegistry([]Team{
+		{Id: 1, Name: "日本大学", Aliases: []string{"日大"}},
+		{Id: 2, Name: "日本体育大学", Aliases: []string{"日大"}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestTeamRegistry_Teams(t *testing.T) {
+	registry, _ := NewTeamRegistry([]Team{{Id: 2, Name: "b"}, {Id: 1, Name: "a"}})
+	assert.Equal(t, []Team{{Id: 1, Name: "a"}, {Id: 2, Name: "b"}}, registry.Teams())
+}
+
+func TestTeamRegistry_Add_SameId(t *testing.T) {
+	registry, _ := NewTeamRegistry([]Team{{Id: 1, Name: "東洋大学"}, {Id: 3, Name: "箱根大学"}})
+
+	assert.Nil(t, registry.Add(Team{Id: 1, Name: "東洋大学", Aliases: []string{"Toyo"}}))
+
+	team, ok := registry.Resolve("toyo")
+	assert.True(t, ok)
+	assert.Equal(t, 1, team.Id)
+	assert.Equal(t, 2, len(registry.Teams()))
+	assert.Equal(t, 4, registry.NextId())
+}
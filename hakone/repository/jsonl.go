@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+	"os"
+	"sort"
+)
+
+// JsonlRepository holds teams and records loaded from jsonl files, and implements repositories of usecase.
+// Team names are compared after hakone.NormalizeTeamName, so "東洋大学" finds records of "東洋大".
+type JsonlRepository struct {
+	teams    []hakone.Team
+	records  []hakone.Record
+	registry *hakone.TeamRegistry
+	byTeam   map[string][]int
+	byPlace  map[int][]int
+}
+
+// NewJsonlRepository indexes teams and records, it fails when names of teams conflict or teams and records are of more
+// than one edition, use NewJsonlEditions for them.
+func NewJsonlRepository(teams []hakone.Team, records []hakone.Record) (*JsonlRepository, error) {
+	if editions := editionsOf(teams, records); len(editions) > 1 {
+		return nil, errors.Errorf("teams and records are of editions %v, use Edition of JsonlEditions", editions)
+	}
+	registry, err := hakone.NewTeamRegistry(teams)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid teams")
+	}
+	repository := JsonlRepository{
+		teams:    teams,
+		records:  records,
+		registry: registry,
+		byTeam:   make(map[string][]int),
+		byPlace:  make(map[int][]int),
+	}
+	for index, record := range records {
+		key := hakone.NormalizeTeamName(string(record.Team))
+		repository.byTeam[key] = append(repository.byTeam[key], index)
+		if record.Place > 0 {
+			repository.byPlace[record.Place] = append(repository.byPlace[record.Place], index)
+		}
+	}
+	return &repository, nil
+}
+
+// Load reads the teams file and the personal result file.
+func Load(teamsPath, personalPath string) (*JsonlRepository, error) {
+	teams, err := loadTeams(teamsPath)
+	if err != nil {
+		return nil, err
+	}
+	records, err := loadRecords(personalPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewJsonlRepository(teams, records)
+}
+
+// LoadEdition reads files of the edition, known teams are used when the teams file does not exist.
+// Teams and records without the edition, which are written before it was introduced, are given the edition.
+func LoadEdition(edition hakone.Edition) (*JsonlRepository, error) {
+	teams, records, err := loadEdition(edition)
+	if err != nil {
+		return nil, err
+	}
+	return NewJsonlRepository(teams, records)
+}
+
+// JsonlEditions holds teams and records of several editions, queries go through the repository of each edition so that
+// records of different editions are never mixed.
+type JsonlEditions struct {
+	teams   []hakone.Team
+	records []hakone.Record
+}
+
+// NewJsonlEditions fails when names of teams conflict, a team has the same id in every edition.
+func NewJsonlEditions(teams []hakone.Team, records []hakone.Record) (*JsonlEditions, error) {
+	if _, err := hakone.NewTeamRegistry(teams); err != nil {
+		return nil, errors.Wrap(err, "invalid teams")
+	}
+	return &JsonlEditions{teams: teams, records: records}, nil
+}
+
+// LoadEditions reads files of every edition, use Edition to query one of them.
+// It fails when the id of a team differs by the edition.
+func LoadEditions(editions ...hakone.Edition) (*JsonlEditions, error) {
+	teams := make([]hakone.Team, 0)
+	records := make([]hakone.Record, 0)
+	for _, edition := range editions {
+		t, r, err := loadEdition(edition)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, t...)
+		records = append(records, r...)
+	}
+	return NewJsonlEditions(teams, records)
+}
+
+func loadEdition(edition hakone.Edition) ([]hakone.Team, []hakone.Record, error) {
+	var teams []hakone.Team
+	if _, err := os.Stat(edition.TeamsJsonlFile()); os.IsNotExist(err) {
+		teams = hakone.DefaultTeamRegistry().Teams()
+	} else if teams, err = loadTeams(edition.TeamsJsonlFile()); err != nil {
+		return nil, nil, err
+	}
+	records, err := loadRecords(edition.PersonalJsonlFile())
+	if err != nil {
+		return nil, nil, err
+	}
+	for index := range teams {
+		if teams[index].Edition == 0 {
+			teams[index].Edition = edition.Number
+		}
+	}
+	for index := range records {
+		if records[index].Edition == 0 {
+			records[index].Edition = edition.Number
+		}
+	}
+	return teams, records, nil
+}
+
+// LoadTeamRegistry reads teams of every edition written by WriteTeamRegistry in addition to known teams, the file
+// may not exist yet.
+func LoadTeamRegistry(path string) (*hakone.TeamRegistry, error) {
+	registry := hakone.DefaultTeamRegistry()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return registry, nil
+	}
+	teams, err := loadTeams(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		if err := registry.Add(team.InEdition(0)); err != nil {
+			return nil, errors.Wrapf(err, "invalid team in %s", path)
+		}
+	}
+	return registry, nil
+}
+
+// WriteTeamRegistry writes teams of the registry, so that teams found in an edition have the same id in later ones.
+func WriteTeamRegistry(path string, registry *hakone.TeamRegistry) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	encoder := json.NewEncoder(file)
+	for _, team := range registry.Teams() {
+		if err := encoder.Encode(team.InEdition(0)); err != nil {
+			return errors.Wrapf(err, "failed to write team %d", team.Id)
+		}
+	}
+	return nil
+}
+
+func loadTeams(path string) ([]hakone.Team, error) {
+	teams := make([]hakone.Team, 0)
+	err := readJsonl(path, func(decode func(v interface{}) error) error {
+		var team hakone.Team
+		if err := decode(&team); err != nil {
+			return err
+		}
+		teams = append(teams, team)
+		return nil
+	})
+	return teams, err
+}
+
+func loadRecords(path string) ([]hakone.Record, error) {
+	records := make([]hakone.Record, 0)
+	err := readJsonl(path, func(decode func(v interface{}) error) error {
+		var record hakone.Record
+		if err := decode(&record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// maxLineSize is large enough for a record with a long note, bufio.Scanner fails on lines longer than 64KB by default.
+const maxLineSize = 1024 * 1024
+
+func readJsonl(path string, read func(decode func(v interface{}) error) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		err := read(func(v interface{}) error {
+			return json.Unmarshal(line, v)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "invalid json at line %d of %s", i, path)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "failed to read file %s", path)
+	}
+	return nil
+}
+
+// Editions returns numbers of editions of teams and records in ascending order.
+func (e *JsonlEditions) Editions() []int {
+	return editionsOf(e.teams, e.records)
+}
+
+// Edition returns a repository which has only teams and records of the edition.
+func (e *JsonlEditions) Edition(number int) (*JsonlRepository, error) {
+	teams := make([]hakone.Team, 0)
+	for _, team := range e.teams {
+		if team.Edition == number {
+			teams = append(teams, team)
+		}
+	}
+	records := make([]hakone.Record, 0)
+	for _, record := range e.records {
+		if record.Edition == number {
+			records = append(records, record)
+		}
+	}
+	return NewJsonlRepository(teams, records)
+}
+
+func editionsOf(teams []hakone.Team, records []hakone.Record) []int {
+	found := make(map[int]bool)
+	for _, team := range teams {
+		found[team.Edition] = true
+	}
+	for _, record := range records {
+		found[record.Edition] = true
+	}
+	editions := make([]int, 0, len(found))
+	for edition := range found {
+		editions = append(editions, edition)
+	}
+	sort.Ints(editions)
+	return editions
+}
+
+func (r *JsonlRepository) ListAllTeams() []hakone.Team {
+	teams := make([]hakone.Team, len(r.teams))
+	copy(teams, r.teams)
+	return teams
+}
+
+func (r *JsonlRepository) FindTeamByName(name string) (*hakone.Team, error) {
+	team, ok := r.registry.Resolve(name)
+	if !ok {
+		return nil, errors.Errorf("team not found with name \"%s\"", name)
+	}
+	return &team, nil
+}
+
+// FindTop10FinishTimeRecordsByTeamName returns scored records of the team ordered by the gun time.
+func (r *JsonlRepository) FindTop10FinishTimeRecordsByTeamName(name hakone.TeamName) []hakone.Record {
+	records := make([]hakone.Record, 0)
+	for _, record := range r.FindRunnersByTeamName(name) {
+		if record.IsScored() {
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].FinishTime < records[j].FinishTime
+	})
+	if len(records) > 10 {
+		records = records[:10]
+	}
+	return records
+}
+
+func (r *JsonlRepository) ListAllRunners() []hakone.Record {
+	records := make([]hakone.Record, len(r.records))
+	copy(records, r.records)
+	return records
+}
+
+// FindRunnersByTeamName returns records of the team in the order of the file.
+func (r *JsonlRepository) FindRunnersByTeamName(name hakone.TeamName) []hakone.Record {
+	return r.pick(r.byTeam[hakone.NormalizeTeamName(string(name))])
+}
+
+// FindRunnersByPlace returns records of the official place, runners with the same time share the place.
+func (r *JsonlRepository) FindRunnersByPlace(place int) []hakone.Record {
+	return r.pick(r.byPlace[place])
+}
+
+func (r *JsonlRepository) pick(indexes []int) []hakone.Record {
+	records := make([]hakone.Record, len(indexes))
+	for i, index := range indexes {
+		records[i] = r.records[index]
+	}
+	return records
+}
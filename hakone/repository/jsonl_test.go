@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func loadTestRepository(t *testing.T) *JsonlRepository {
+	repository, err := Load("testdata/teams.jsonl", "testdata/personal.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repository
+}
+
+func runners(records []hakone.Record) []hakone.Runner {
+	names := make([]hakone.Runner, len(records))
+	for i, record := range records {
+		names[i] = record.Runner
+	}
+	return names
+}
+
+func TestLoad(t *testing.T) {
+	repository := loadTestRepository(t)
+
+	assert.Equal(t, 2, len(repository.ListAllTeams()))
+	assert.Equal(t, 5, len(repository.ListAllRunners()))
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load("testdata/invalid.jsonl", "testdata/personal.jsonl")
+	assert.NotNil(t, err)
+
+	_, err = Load("testdata/teams.jsonl", "testdata/not-found.jsonl")
+	assert.NotNil(t, err)
+}
+
+func TestJsonlRepository_FindTeamByName(t *testing.T) {
+	repository := loadTestRepository(t)
+
+	for _, name := range []string{"東洋大学", "東洋大", "東京国際大", "東京国際大学"} {
+		team, err := repository.FindTeamByName(name)
+		assert.Nil(t, err, name)
+		assert.NotNil(t, team, name)
+	}
+	_, err := repository.FindTeamByName("箱根大学")
+	assert.NotNil(t, err)
+}
+
+func TestJsonlRepository_FindTop10FinishTimeRecordsByTeamName(t *testing.T) {
+	repository := loadTestRepository(t)
+
+	assert.Equal(t, []hakone.Runner{"山田太郎", "鈴木次郎"}, runners(repository.FindTop10FinishTimeRecordsByTeamName("東洋大学")))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runners(repository.FindTop10FinishTimeRecordsByTeamName("東京国際大学")))
+}
+
+func TestJsonlRepository_FindTop10FinishTimeRecordsByTeamName_Limit(t *testing.T) {
+	records := make([]hakone.Record, 0)
+	for i := 12; i > 0; i-- {
+		records = append(records, hakone.Record{
+			Order:      i,
+			Runner:     hakone.Runner(fmt.Sprintf("r%d", i)),
+			Team:       "東洋大",
+			FinishTime: hakone.Seconds(3600 + i),
+		})
+	}
+	repository, err := NewJsonlRepository([]hakone.Team{{Id: 2, Name: "東洋大"}}, records)
+	assert.Nil(t, err)
+
+	top10 := repository.FindTop10FinishTimeRecordsByTeamName("東洋大")
+
+	assert.Equal(t, 10, len(top10))
+	assert.Equal(t, hakone.Runner("r1"), top10[0].Runner)
+	assert.Equal(t, hakone.Runner("r10"), top10[9].Runner)
+}
+
+func TestJsonlRepository_FindRunners(t *testing.T) {
+	repository := loadTestRepository(t)
+
+	assert.Equal(t, []hakone.Runner{"山田太郎", "鈴木次郎", "佐藤三郎", "田中四郎"}, runners(repository.FindRunnersByTeamName("東洋大")))
+	assert.Equal(t, []hakone.Runner{"山田太郎", "鈴木次郎"}, runners(repository.FindRunnersByPlace(2)))
+	assert.Empty(t, repository.FindRunnersByPlace(0))
+}
+
+func TestJsonlEditions_Edition(t *testing.T) {
+	teams := []hakone.Team{
+		{Id: 2, Edition: 95, Name: "東洋大学", ShortName: "東洋大"},
+		{Id: 2, Edition: 96, Name: "東洋大学", ShortName: "東洋大"},
+		{Id: 6, Edition: 96, Name: "東京国際大学"},
+	}
+	records := []hakone.Record{
+		{Edition: 95, Place: 1, Bib: 1, Runner: "山田太郎", Team: "東洋大", FinishTime: hakone.Seconds(3760)},
+		{Edition: 96, Place: 1, Bib: 42, Runner: "ムセンビ", Team: "東京国際大", FinishTime: hakone.Seconds(3743)},
+		{Edition: 96, Place: 2, Bib: 108, Runner: "山田太郎", Team: "東洋大", FinishTime: hakone.Seconds(3750)},
+	}
+	_, err := NewJsonlRepository(teams, records)
+	assert.NotNil(t, err)
+
+	repository, err := NewJsonlEditions(teams, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []int{95, 96}, repository.Editions())
+
+	edition, err := repository.Edition(95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(edition.ListAllTeams()))
+	assert.Equal(t, []hakone.Runner{"山田太郎"}, runners(edition.FindRunnersByTeamName("東洋大学")))
+	assert.Equal(t, 95, edition.FindRunnersByPlace(1)[0].Edition)
+
+	edition, err = repository.Edition(96)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(edition.ListAllTeams()))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runners(edition.FindRunnersByPlace(1)))
+}
+
+func TestWriteTeamRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	path := filepath.Join(dir, "teams.jsonl")
+	registry := hakone.DefaultTeamRegistry()
+	assert.Nil(t, registry.Add(hakone.Team{Id: registry.NextId(), Name: "箱根大学"}))
+
+	assert.Nil(t, WriteTeamRegistry(path, registry))
+	loaded, err := LoadTeamRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, registry.Teams(), loaded.Teams())
+	team, ok := loaded.Resolve("箱根大")
+	assert.True(t, ok)
+	assert.Equal(t, 21, team.Id)
+}
+
+func TestLoadTeamRegistry_NotExist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	registry, err := LoadTeamRegistry(filepath.Join(dir, "teams.jsonl"))
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.DefaultTeamRegistry().Teams(), registry.Teams())
+}
@@ -0,0 +1,39 @@
+package hakone
+
+// Entrant is a runner registered in the entry list with personal bests, zero means no record.
+type Entrant struct {
+	Runner     Runner `json:"runner"`
+	Grade      Grade  `json:"grade"`
+	Best10000m Time   `json:"best_10000m"`
+	BestHalf   Time   `json:"best_half"`
+}
+
+// Roster is the entry list of a team.
+type Roster struct {
+	Team     Team      `json:"team"`
+	Entrants []Entrant `json:"entrants"`
+}
+
+// Compare returns entrants without records and records of runners not in the roster, records should be of the team.
+func (r Roster) Compare(records []Record) ([]Entrant, []Record) {
+	entrants := make(map[string]bool, len(r.Entrants))
+	for _, entrant := range r.Entrants {
+		entrants[NormalizeRunnerName(string(entrant.Runner))] = true
+	}
+	recorded := make(map[string]bool, len(records))
+	unregistered := make([]Record, 0)
+	for _, record := range records {
+		name := NormalizeRunnerName(string(record.Runner))
+		recorded[name] = true
+		if !entrants[name] {
+			unregistered = append(unregistered, record)
+		}
+	}
+	absent := make([]Entrant, 0)
+	for _, entrant := range r.Entrants {
+		if !recorded[NormalizeRunnerName(string(entrant.Runner))] {
+			absent = append(absent, entrant)
+		}
+	}
+	return absent, unregistered
+}
@@ -0,0 +1,27 @@
+package hakone
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoster_Compare(t *testing.T) {
+	roster := Roster{
+		Team: Team{Id: 1, Name: "東洋大学"},
+		Entrants: []Entrant{
+			{Runner: "山田　太郎", Grade: 3},
+			{Runner: "鈴木次郎", Grade: 1},
+			{Runner: "佐藤三郎", Grade: 2},
+		},
+	}
+	records := []Record{
+		{Runner: "山田太郎", Team: "東洋大"},
+		{Runner: "佐藤 三郎", Team: "東洋大"},
+		{Runner: "田中四郎", Team: "東洋大"},
+	}
+
+	absent, unregistered := roster.Compare(records)
+
+	assert.Equal(t, []Entrant{{Runner: "鈴木次郎", Grade: 1}}, absent)
+	assert.Equal(t, []Record{{Runner: "田中四郎", Team: "東洋大"}}, unregistered)
+}
@@ -0,0 +1,100 @@
+package hakone
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// Status is a finish status of the runner.
+// The zero value is StatusFinished, because records written before Status was introduced have only finishers.
+type Status int
+
+const (
+	StatusFinished Status = iota
+	StatusDNF
+	StatusDNS
+	StatusDQ
+	StatusOpen
+)
+
+var statusNames = map[Status]string{
+	StatusFinished: "finished",
+	StatusDNF:      "DNF",
+	StatusDNS:      "DNS",
+	StatusDQ:       "DQ",
+	StatusOpen:     "OPEN",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Finished returns true when the runner reached the finish line, including open entries.
+func (s Status) Finished() bool {
+	return s == StatusFinished || s == StatusOpen
+}
+
+// IsScored returns true when the runner is counted for the team total.
+func (s Status) IsScored() bool {
+	return s == StatusFinished
+}
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	name, ok := statusNames[s]
+	if !ok {
+		return nil, errors.Errorf("unknown status: %d", int(s))
+	}
+	return json.Marshal(name)
+}
+
+func (s *Status) UnmarshalJSON(bytes []byte) error {
+	var name string
+	if err := json.Unmarshal(bytes, &name); err != nil {
+		return errors.Wrapf(err, "status should be string: %s", string(bytes))
+	}
+	for status, n := range statusNames {
+		if n == name {
+			*s = status
+			return nil
+		}
+	}
+	return errors.Errorf("unknown status: %s", name)
+}
+
+var noteCodes = []struct {
+	prefix string
+	status Status
+}{
+	{"DNS", StatusDNS},
+	{"欠場", StatusDNS},
+	{"DNF", StatusDNF},
+	{"途中棄権", StatusDNF},
+	{"棄権", StatusDNF},
+	{"DQ", StatusDQ},
+	{"失格", StatusDQ},
+	{"OP", StatusOpen},
+	{"オープン", StatusOpen},
+}
+
+// ParseStatus converts the note code of the result sheet, an empty note means the runner finished.
+func ParseStatus(code string) (Status, error) {
+	c := strings.ToUpper(strings.TrimSpace(code))
+	if c == "" {
+		return StatusFinished, nil
+	}
+	for _, nc := range noteCodes {
+		if strings.HasPrefix(c, nc.prefix) {
+			return nc.status, nil
+		}
+	}
+	return StatusFinished, errors.Errorf("unknown note code: %s", code)
+}
+
+func (n Note) Status() (Status, error) {
+	return ParseStatus(string(n))
+}
@@ -0,0 +1,62 @@
+package hakone
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	cases := map[string]Status{
+		"":     StatusFinished,
+		"DNS":  StatusDNS,
+		"欠場":   StatusDNS,
+		"DNF":  StatusDNF,
+		"途中棄権": StatusDNF,
+		"DQ2":  StatusDQ,
+		"失格":   StatusDQ,
+		"OPEN": StatusOpen,
+		"op":   StatusOpen,
+	}
+	for code, expected := range cases {
+		status, err := ParseStatus(code)
+		assert.Nil(t, err, code)
+		assert.Equal(t, expected, status, code)
+	}
+}
+
+func TestParseStatus_Unknown(t *testing.T) {
+	_, err := ParseStatus("XYZ")
+	assert.NotNil(t, err)
+}
+
+func TestStatus_Predicates(t *testing.T) {
+	assert.True(t, StatusFinished.Finished())
+	assert.True(t, StatusFinished.IsScored())
+	assert.True(t, StatusOpen.Finished())
+	assert.False(t, StatusOpen.IsScored())
+	assert.False(t, StatusDNF.Finished())
+	assert.False(t, StatusDQ.IsScored())
+}
+
+func TestStatus_JSON(t *testing.T) {
+	bytes, err := json.Marshal(StatusDNF)
+	assert.Nil(t, err)
+	assert.Equal(t, `"DNF"`, string(bytes))
+
+	var status Status
+	err = json.Unmarshal([]byte(`"OPEN"`), &status)
+	assert.Nil(t, err)
+	assert.Equal(t, StatusOpen, status)
+
+	err = json.Unmarshal([]byte(`"unknown"`), &status)
+	assert.NotNil(t, err)
+}
+
+func TestRecord_JSON_WithoutStatus(t *testing.T) {
+	var record Record
+	err := json.Unmarshal([]byte(`{"order":1,"runner":"山田太郎","finish_time":3723}`), &record)
+	assert.Nil(t, err)
+	assert.Equal(t, StatusFinished, record.Status)
+	assert.True(t, record.IsScored())
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/mike-neck/go-hakone-qualification/hakone/store"
+	"log"
+)
+
+func main() {
+	database := flag.String("db", "data/hakone.db", "path of the SQLite database")
+	editionNumber := flag.Int("edition", hakone.DefaultEditionNumber, "edition number of the race")
+	flag.Parse()
+	edition := hakone.NewEdition(*editionNumber)
+
+	db, err := store.Open(*database)
+	if err != nil {
+		log.Fatalln("failed to open database", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if err := db.ImportEdition(edition); err != nil {
+		log.Fatalln("failed to import", err)
+	}
+	repository, err := db.Edition(edition.Number)
+	if err != nil {
+		log.Fatalln("failed to read imported edition", err)
+	}
+	log.Println("imported edition", edition.Number, "teams", len(repository.ListAllTeams()), "records", len(repository.ListAllRunners()))
+}
@@ -0,0 +1,37 @@
+module github.com/mike-neck/go-hakone-qualification/hakone/store
+
+go 1.21
+
+require (
+	github.com/mike-neck/go-hakone-qualification/hakone v0.0.0-20191101003604-c3c67b81207f
+	github.com/pkg/errors v0.8.1
+	github.com/stretchr/testify v1.4.0
+	modernc.org/sqlite v1.29.10
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
+
+require (
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/hashicorp/golang-lru/v2 v2.0.7 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mike-neck/go-hakone-qualification/hakone/usecase v0.0.0-20191101003604-c3c67b81207f
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/sys v0.19.0 // indirect
+	modernc.org/gc/v3 v3.0.0-20240107210532-573471604cb6 // indirect
+	modernc.org/libc v1.49.3 // indirect
+	modernc.org/mathutil v1.6.0 // indirect
+	modernc.org/memory v1.8.0 // indirect
+	modernc.org/strutil v1.2.0 // indirect
+	modernc.org/token v1.1.0 // indirect
+)
+
+replace github.com/mike-neck/go-hakone-qualification/hakone => ../
+
+replace github.com/mike-neck/go-hakone-qualification/hakone/usecase => ../usecase
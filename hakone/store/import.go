@@ -0,0 +1,158 @@
+package store
+
+import (
+	"database/sql"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/mike-neck/go-hakone-qualification/hakone/repository"
+	"github.com/pkg/errors"
+)
+
+// ImportEdition imports the jsonl files of the edition written by the commands.
+func (s *Store) ImportEdition(edition hakone.Edition) error {
+	jsonl, err := repository.LoadEdition(edition)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load edition %d", edition.Number)
+	}
+	return s.Import(edition, jsonl.ListAllTeams(), jsonl.ListAllRunners())
+}
+
+// Import replaces teams and results of the edition.
+// A team already stored under any of its names keeps the stored id, so that the same team has the same id in every
+// edition. Other teams get the id in the file, or a new id when it is used by another team.
+func (s *Store) Import(edition hakone.Edition, teams []hakone.Team, records []hakone.Record) error {
+	edition = edition.WithDefaults()
+	return s.transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT INTO editions (number, qualifying_slots, scored_runners_per_team, max_entrants_per_team, reference_pace)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (number) DO UPDATE SET
+				qualifying_slots = excluded.qualifying_slots,
+				scored_runners_per_team = excluded.scored_runners_per_team,
+				max_entrants_per_team = excluded.max_entrants_per_team,
+				reference_pace = excluded.reference_pace`,
+			edition.Number, edition.QualifyingSlots, edition.ScoredRunnersPerTeam, edition.MaxEntrantsPerTeam, int(edition.ReferencePace))
+		if err != nil {
+			return errors.Wrapf(err, "failed to save edition %d", edition.Number)
+		}
+		for _, table := range []string{"results", "edition_teams"} {
+			if _, err := tx.Exec(`DELETE FROM `+table+` WHERE edition = ?`, edition.Number); err != nil {
+				return errors.Wrapf(err, "failed to delete %s of edition %d", table, edition.Number)
+			}
+		}
+
+		stored, err := loadTeams(tx, `SELECT id, name, short_name, reading, english_name FROM teams`)
+		if err != nil {
+			return err
+		}
+		registry, err := hakone.NewTeamRegistry(stored)
+		if err != nil {
+			return errors.Wrap(err, "stored teams conflict")
+		}
+		for _, team := range teams {
+			id, err := saveTeam(tx, registry, team)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(`INSERT OR IGNORE INTO edition_teams (edition, team_id) VALUES (?, ?)`, edition.Number, id)
+			if err != nil {
+				return errors.Wrapf(err, "failed to save team %s of edition %d", team.Name, edition.Number)
+			}
+		}
+
+		for _, record := range records {
+			if err := saveResult(tx, registry, edition, record); err != nil {
+				return errors.Wrapf(err, "failed to save result of %s in order %d", record.Runner, record.Order)
+			}
+		}
+		return nil
+	})
+}
+
+// saveTeam returns the stored id of the team, names which are new to the stored team are saved as aliases.
+func saveTeam(tx *sql.Tx, registry *hakone.TeamRegistry, team hakone.Team) (int, error) {
+	for _, name := range team.Names() {
+		existing, ok := registry.Resolve(name)
+		if !ok {
+			continue
+		}
+		for _, other := range team.Names() {
+			if _, ok := registry.Resolve(other); ok {
+				continue
+			}
+			if err := saveAlias(tx, existing.Id, other); err != nil {
+				return 0, err
+			}
+			existing.Aliases = append(existing.Aliases, other)
+			if err := registry.Add(existing); err != nil {
+				return 0, err
+			}
+		}
+		return existing.Id, nil
+	}
+
+	var used int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM teams WHERE id = ?`, team.Id).Scan(&used); err != nil {
+		return 0, errors.Wrap(err, "failed to find team id")
+	}
+	if used > 0 || team.Id <= 0 {
+		if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM teams`).Scan(&team.Id); err != nil {
+			return 0, errors.Wrap(err, "failed to assign team id")
+		}
+	}
+	_, err := tx.Exec(`INSERT INTO teams (id, name, short_name, reading, english_name) VALUES (?, ?, ?, ?, ?)`,
+		team.Id, team.Name, team.ShortName, team.Reading, team.EnglishName)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to save team %s", team.Name)
+	}
+	for _, alias := range team.Aliases {
+		if err := saveAlias(tx, team.Id, alias); err != nil {
+			return 0, err
+		}
+	}
+	return team.Id, registry.Add(team)
+}
+
+func saveAlias(tx *sql.Tx, id int, alias string) error {
+	_, err := tx.Exec(`INSERT OR IGNORE INTO team_aliases (team_id, alias) VALUES (?, ?)`, id, alias)
+	return errors.Wrapf(err, "failed to save alias %s of team %d", alias, id)
+}
+
+// saveResult saves the runner as the same runner when the normalized name and the team are the same as a stored one.
+// Runners of teams which are not resolved are told apart by the team name printed on the sheet.
+func saveResult(tx *sql.Tx, registry *hakone.TeamRegistry, edition hakone.Edition, record hakone.Record) error {
+	teamId := 0
+	teamKey := hakone.NormalizeTeamName(string(record.Team))
+	runnerTeamKey := teamKey
+	if team, ok := registry.Resolve(string(record.Team)); ok {
+		teamId = team.Id
+		runnerTeamKey = ""
+	}
+	var runnerId int
+	err := tx.QueryRow(`INSERT INTO runners (team_id, team_key, name, normalized_name, romanized_name, nationality)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (team_id, team_key, normalized_name) DO UPDATE SET
+			name = excluded.name,
+			romanized_name = excluded.romanized_name,
+			nationality = excluded.nationality
+		RETURNING id`,
+		teamId, runnerTeamKey, string(record.Runner), hakone.NormalizeRunnerName(string(record.Runner)), record.RomanizedName, record.Nationality,
+	).Scan(&runnerId)
+	if err != nil {
+		return errors.Wrapf(err, "failed to save runner %s", record.Runner)
+	}
+	var bib interface{}
+	if record.Bib != 0 {
+		bib = record.Bib
+	}
+	_, err = tx.Exec(`INSERT INTO results (
+			edition, bib, runner_id, team_id, team_name, team_key, order_number, place, grade,
+			time_of_5_km, time_of_10_km, time_of_15_km, time_of_20_km, finish_time, net_time,
+			rap_5_to_10, rap_10_to_15, rap_15_to_20, note, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		edition.Number, bib, runnerId, teamId, string(record.Team), teamKey,
+		record.Order, record.Place, int(record.Grade),
+		int(record.TimeOf5km), int(record.TimeOf10km), int(record.TimeOf15km), int(record.TimeOf20km),
+		int(record.FinishTime), int(record.NetTime),
+		int(record.RapFrom5kmTo10km), int(record.RapFrom10kmTo15km), int(record.RapFrom15kmTo20km),
+		string(record.Note), int(record.Status))
+	return err
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"database/sql"
+	"github.com/pkg/errors"
+)
+
+// migrations are applied in order, the version of the database is the number of applied migrations.
+// Never edit an applied migration, append a new one instead.
+var migrations = []string{
+	`CREATE TABLE editions (
+		number                  INTEGER PRIMARY KEY,
+		qualifying_slots        INTEGER NOT NULL,
+		scored_runners_per_team INTEGER NOT NULL,
+		max_entrants_per_team   INTEGER NOT NULL,
+		reference_pace          INTEGER NOT NULL
+	);
+	CREATE TABLE teams (
+		id           INTEGER PRIMARY KEY,
+		name         TEXT NOT NULL UNIQUE,
+		short_name   TEXT NOT NULL DEFAULT '',
+		reading      TEXT NOT NULL DEFAULT '',
+		english_name TEXT NOT NULL DEFAULT ''
+	);
+	CREATE TABLE team_aliases (
+		team_id INTEGER NOT NULL REFERENCES teams (id),
+		alias   TEXT NOT NULL,
+		PRIMARY KEY (team_id, alias)
+	);
+	CREATE TABLE edition_teams (
+		edition INTEGER NOT NULL REFERENCES editions (number),
+		team_id INTEGER NOT NULL REFERENCES teams (id),
+		PRIMARY KEY (edition, team_id)
+	);
+	CREATE TABLE runners (
+		id              INTEGER PRIMARY KEY AUTOINCREMENT,
+		team_id         INTEGER NOT NULL DEFAULT 0,
+		-- the normalized name of the team printed on the sheet when the team is not resolved, otherwise empty.
+		team_key        TEXT NOT NULL DEFAULT '',
+		name            TEXT NOT NULL,
+		normalized_name TEXT NOT NULL,
+		romanized_name  TEXT NOT NULL DEFAULT '',
+		nationality     TEXT NOT NULL DEFAULT '',
+		UNIQUE (team_id, team_key, normalized_name)
+	);
+	CREATE TABLE results (
+		edition           INTEGER NOT NULL REFERENCES editions (number),
+		-- results written before the bib was introduced have no bib.
+		bib               INTEGER,
+		runner_id         INTEGER NOT NULL REFERENCES runners (id),
+		team_id           INTEGER NOT NULL DEFAULT 0,
+		team_name         TEXT NOT NULL,
+		team_key          TEXT NOT NULL,
+		order_number      INTEGER NOT NULL,
+		place             INTEGER NOT NULL,
+		grade             INTEGER NOT NULL,
+		time_of_5_km      INTEGER NOT NULL,
+		time_of_10_km     INTEGER NOT NULL,
+		time_of_15_km     INTEGER NOT NULL,
+		time_of_20_km     INTEGER NOT NULL,
+		finish_time       INTEGER NOT NULL,
+		net_time          INTEGER NOT NULL,
+		rap_5_to_10       INTEGER NOT NULL,
+		rap_10_to_15      INTEGER NOT NULL,
+		rap_15_to_20      INTEGER NOT NULL,
+		note              TEXT NOT NULL,
+		status            INTEGER NOT NULL
+	);
+	CREATE UNIQUE INDEX results_bib ON results (edition, bib) WHERE bib IS NOT NULL;
+	CREATE INDEX results_team ON results (edition, team_id, finish_time);
+	CREATE INDEX results_team_key ON results (edition, team_key);`,
+}
+
+// Version returns the number of applied migrations.
+func (s *Store) Version() (int, error) {
+	var version int
+	err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read schema version")
+	}
+	return version, nil
+}
+
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to create schema_migrations")
+	}
+	version, err := s.Version()
+	if err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return errors.Errorf("database version %d is newer than this program supports %d", version, len(migrations))
+	}
+	for index := version; index < len(migrations); index++ {
+		err := s.transaction(func(tx *sql.Tx) error {
+			if _, err := tx.Exec(migrations[index]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, index+1)
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate to version %d", index+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"database/sql"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+)
+
+// EditionRepository implements repositories of usecase with teams and results of an edition in the store, teams and
+// records have the edition.
+// Methods of usecase repositories cannot return errors, so they return empty results on errors and Err returns the
+// first one.
+type EditionRepository struct {
+	store    *Store
+	edition  int
+	teams    []hakone.Team
+	registry *hakone.TeamRegistry
+	err      error
+}
+
+// Edition loads teams of the edition, it fails when the edition is not imported.
+func (s *Store) Edition(number int) (*EditionRepository, error) {
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM editions WHERE number = ?`, number).Scan(&count); err != nil {
+		return nil, errors.Wrapf(err, "failed to find edition %d", number)
+	}
+	if count == 0 {
+		return nil, errors.Errorf("edition %d is not imported", number)
+	}
+	teams, err := loadTeams(s.db, `SELECT t.id, t.name, t.short_name, t.reading, t.english_name
+		FROM teams t JOIN edition_teams e ON e.team_id = t.id
+		WHERE e.edition = ?`, number)
+	if err != nil {
+		return nil, err
+	}
+	for index := range teams {
+		teams[index].Edition = number
+	}
+	registry, err := hakone.NewTeamRegistry(teams)
+	if err != nil {
+		return nil, errors.Wrapf(err, "teams of edition %d conflict", number)
+	}
+	return &EditionRepository{store: s, edition: number, teams: teams, registry: registry}, nil
+}
+
+// Editions returns numbers of imported editions in ascending order.
+func (s *Store) Editions() ([]int, error) {
+	rows, err := s.db.Query(`SELECT number FROM editions ORDER BY number`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query editions")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	editions := make([]int, 0)
+	for rows.Next() {
+		var number int
+		if err := rows.Scan(&number); err != nil {
+			return nil, errors.Wrap(err, "failed to read editions")
+		}
+		editions = append(editions, number)
+	}
+	return editions, rows.Err()
+}
+
+// Err returns the first error which occurred while querying results.
+func (r *EditionRepository) Err() error {
+	return r.err
+}
+
+func (r *EditionRepository) ListAllTeams() []hakone.Team {
+	teams := make([]hakone.Team, len(r.teams))
+	copy(teams, r.teams)
+	return teams
+}
+
+func (r *EditionRepository) FindTeamByName(name string) (*hakone.Team, error) {
+	team, ok := r.registry.Resolve(name)
+	if !ok {
+		return nil, errors.Errorf("team not found with name \"%s\"", name)
+	}
+	return &team, nil
+}
+
+// FindTop10FinishTimeRecordsByTeamName returns scored records of the team ordered by the gun time.
+func (r *EditionRepository) FindTop10FinishTimeRecordsByTeamName(name hakone.TeamName) []hakone.Record {
+	condition, key := r.teamCondition(name)
+	return r.query(condition+` AND r.status = ? AND r.finish_time > 0 ORDER BY r.finish_time, r.order_number, r.rowid LIMIT 10`,
+		key, int(hakone.StatusFinished))
+}
+
+func (r *EditionRepository) ListAllRunners() []hakone.Record {
+	return r.query(`ORDER BY r.rowid`)
+}
+
+// FindRunnersByTeamName returns records of the team in the order of the import.
+func (r *EditionRepository) FindRunnersByTeamName(name hakone.TeamName) []hakone.Record {
+	condition, key := r.teamCondition(name)
+	return r.query(condition+` ORDER BY r.rowid`, key)
+}
+
+// teamCondition finds results by the team id when the name is resolved, otherwise by the name printed on the sheet.
+func (r *EditionRepository) teamCondition(name hakone.TeamName) (string, interface{}) {
+	if team, ok := r.registry.Resolve(string(name)); ok {
+		return `AND r.team_id = ?`, team.Id
+	}
+	return `AND r.team_key = ?`, hakone.NormalizeTeamName(string(name))
+}
+
+func (r *EditionRepository) query(condition string, args ...interface{}) []hakone.Record {
+	records := make([]hakone.Record, 0)
+	rows, err := r.store.db.Query(`SELECT
+			r.order_number, r.place, COALESCE(r.bib, 0), u.name, u.romanized_name, u.nationality, r.grade, r.team_name,
+			r.time_of_5_km, r.time_of_10_km, r.time_of_15_km, r.time_of_20_km, r.finish_time, r.net_time,
+			r.rap_5_to_10, r.rap_10_to_15, r.rap_15_to_20, r.note, r.status
+		FROM results r JOIN runners u ON u.id = r.runner_id
+		WHERE r.edition = ? `+condition, append([]interface{}{r.edition}, args...)...)
+	if err != nil {
+		r.fail(errors.Wrapf(err, "failed to query results of edition %d", r.edition))
+		return records
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		record := hakone.Record{Edition: r.edition}
+		err := rows.Scan(
+			&record.Order, &record.Place, &record.Bib, &record.Runner, &record.RomanizedName, &record.Nationality,
+			&record.Grade, &record.Team,
+			&record.TimeOf5km, &record.TimeOf10km, &record.TimeOf15km, &record.TimeOf20km, &record.FinishTime,
+			&record.NetTime, &record.RapFrom5kmTo10km, &record.RapFrom10kmTo15km, &record.RapFrom15kmTo20km,
+			&record.Note, &record.Status)
+		if err != nil {
+			r.fail(errors.Wrapf(err, "failed to read results of edition %d", r.edition))
+			return make([]hakone.Record, 0)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		r.fail(errors.Wrapf(err, "failed to read results of edition %d", r.edition))
+		return make([]hakone.Record, 0)
+	}
+	return records
+}
+
+func (r *EditionRepository) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// loadTeams reads teams with their aliases ordered by id, the query selects id, name, short_name, reading and
+// english_name of teams.
+func loadTeams(db queryer, query string, args ...interface{}) ([]hakone.Team, error) {
+	teams := make([]hakone.Team, 0)
+	rows, err := db.Query(query+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query teams")
+	}
+	for rows.Next() {
+		var team hakone.Team
+		if err := rows.Scan(&team.Id, &team.Name, &team.ShortName, &team.Reading, &team.EnglishName); err != nil {
+			_ = rows.Close()
+			return nil, errors.Wrap(err, "failed to read teams")
+		}
+		teams = append(teams, team)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read teams")
+	}
+
+	aliases := make(map[int][]string)
+	rows, err = db.Query(`SELECT team_id, alias FROM team_aliases ORDER BY rowid`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query aliases")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var id int
+		var alias string
+		if err := rows.Scan(&id, &alias); err != nil {
+			return nil, errors.Wrap(err, "failed to read aliases")
+		}
+		aliases[id] = append(aliases[id], alias)
+	}
+	for index := range teams {
+		teams[index].Aliases = aliases[teams[index].Id]
+	}
+	return teams, rows.Err()
+}
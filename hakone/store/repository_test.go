@@ -0,0 +1,231 @@
+package store
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/mike-neck/go-hakone-qualification/hakone/repository"
+	"github.com/mike-neck/go-hakone-qualification/hakone/usecase"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	_ usecase.TeamRepository         = (*EditionRepository)(nil)
+	_ usecase.Top10RecordsRepository = (*EditionRepository)(nil)
+	_ usecase.RunnerRepository       = (*EditionRepository)(nil)
+)
+
+func openTestStore(t *testing.T) *Store {
+	store, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	return store
+}
+
+func loadTestJsonl(t *testing.T) *repository.JsonlRepository {
+	jsonl, err := repository.Load("../repository/testdata/teams.jsonl", "../repository/testdata/personal.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jsonl
+}
+
+func importTestEdition(t *testing.T, store *Store, number int) *EditionRepository {
+	jsonl := loadTestJsonl(t)
+	if err := store.Import(hakone.NewEdition(number), jsonl.ListAllTeams(), jsonl.ListAllRunners()); err != nil {
+		t.Fatal(err)
+	}
+	edition, err := store.Edition(number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return edition
+}
+
+func runners(records []hakone.Record) []hakone.Runner {
+	names := make([]hakone.Runner, len(records))
+	for i, record := range records {
+		names[i] = record.Runner
+	}
+	return names
+}
+
+func TestStore_Import(t *testing.T) {
+	edition := importTestEdition(t, openTestStore(t), 96)
+
+	records := loadTestJsonl(t).ListAllRunners()
+	for index := range records {
+		records[index].Edition = 96
+	}
+	assert.Equal(t, records, edition.ListAllRunners())
+	assert.Equal(t, []int{2, 6}, teamIds(edition.ListAllTeams()))
+	assert.Equal(t, 96, edition.ListAllTeams()[0].Edition)
+	assert.Nil(t, edition.Err())
+}
+
+func TestStore_Editions(t *testing.T) {
+	store := openTestStore(t)
+	importTestEdition(t, store, 97)
+	importTestEdition(t, store, 96)
+	err := store.Import(hakone.NewEdition(95), []hakone.Team{{Id: 2, Name: "東洋大学"}}, []hakone.Record{
+		{Order: 1, Place: 1, Bib: 1, Runner: "山田太郎", Grade: 2, Team: "東洋大", FinishTime: hakone.Seconds(3760)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editions, err := store.Editions()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{95, 96, 97}, editions)
+
+	edition, err := store.Edition(95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := edition.FindRunnersByTeamName("東洋大学")
+	assert.Equal(t, []hakone.Runner{"山田太郎"}, runners(records))
+	assert.Equal(t, 95, records[0].Edition)
+}
+
+func TestStore_Import_Again(t *testing.T) {
+	store := openTestStore(t)
+	importTestEdition(t, store, 96)
+	edition := importTestEdition(t, store, 96)
+
+	assert.Equal(t, 5, len(edition.ListAllRunners()))
+	assert.Equal(t, 2, len(edition.ListAllTeams()))
+}
+
+func TestStore_Import_SameTeamId(t *testing.T) {
+	store := openTestStore(t)
+	importTestEdition(t, store, 96)
+	teams := []hakone.Team{
+		{Id: 2, Name: "箱根大学"},
+		{Id: 9, Name: "東洋大", Aliases: []string{"Toyo"}},
+	}
+	records := []hakone.Record{
+		{Order: 1, Place: 1, Bib: 1, Runner: "山田 太郎", Grade: 4, Team: "東洋大", FinishTime: hakone.Seconds(3700)},
+		{Order: 2, Place: 2, Bib: 2, Runner: "箱根一郎", Grade: 1, Team: "箱根大", FinishTime: hakone.Seconds(3800)},
+	}
+	if err := store.Import(hakone.NewEdition(97), teams, records); err != nil {
+		t.Fatal(err)
+	}
+	edition, err := store.Edition(97)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []int{2, 7}, teamIds(edition.ListAllTeams()))
+	team, err := edition.FindTeamByName("toyo")
+	assert.Nil(t, err)
+	if team != nil {
+		assert.Equal(t, "東洋大学", team.Name)
+	}
+
+	var count int
+	err = store.db.QueryRow(`SELECT COUNT(*) FROM runners WHERE normalized_name = ?`, hakone.NormalizeRunnerName("山田太郎")).Scan(&count)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestStore_Import_UnresolvedTeams(t *testing.T) {
+	store := openTestStore(t)
+	err := store.Import(hakone.NewEdition(96), []hakone.Team{}, []hakone.Record{
+		{Order: 1, Place: 1, Bib: 1, Runner: "山田太郎", Grade: 2, Team: "箱根大", FinishTime: hakone.Seconds(3700)},
+		{Order: 2, Place: 2, Bib: 2, Runner: "山田 太郎", Grade: 3, Team: "芦ノ湖大", FinishTime: hakone.Seconds(3800)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+	err = store.db.QueryRow(`SELECT COUNT(DISTINCT runner_id) FROM results WHERE edition = 96`).Scan(&count)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestStore_Import_WithoutBib(t *testing.T) {
+	store := openTestStore(t)
+	jsonl, err := repository.Load("../repository/testdata/teams.jsonl", "testdata/legacy.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Import(hakone.NewEdition(96), jsonl.ListAllTeams(), jsonl.ListAllRunners()); err != nil {
+		t.Fatal(err)
+	}
+	edition, err := store.Edition(96)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := edition.ListAllRunners()
+	assert.Equal(t, []hakone.Runner{"ムセンビ", "山田太郎"}, runners(records))
+	assert.Equal(t, []int{0, 0}, []int{records[0].Bib, records[1].Bib})
+	assert.Equal(t, hakone.Grade(2), records[0].Grade)
+	assert.Equal(t, hakone.Seconds(3743), records[0].FinishTime)
+	assert.Nil(t, edition.Err())
+
+	err = store.Import(hakone.NewEdition(97), jsonl.ListAllTeams(), []hakone.Record{
+		{Order: 1, Place: 1, Bib: 1, Runner: "山田太郎", Grade: 3, Team: "東洋大", FinishTime: hakone.Seconds(3700)},
+		{Order: 2, Place: 2, Bib: 1, Runner: "箱根一郎", Grade: 1, Team: "東洋大", FinishTime: hakone.Seconds(3800)},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestStore_Edition_NotImported(t *testing.T) {
+	_, err := openTestStore(t).Edition(96)
+	assert.NotNil(t, err)
+}
+
+func TestEditionRepository_FindTeamByName(t *testing.T) {
+	edition := importTestEdition(t, openTestStore(t), 96)
+
+	for _, name := range []string{"東洋大学", "東洋大", "東京国際大", "東京国際大学"} {
+		team, err := edition.FindTeamByName(name)
+		assert.Nil(t, err, name)
+		assert.NotNil(t, team, name)
+	}
+	_, err := edition.FindTeamByName("箱根大学")
+	assert.NotNil(t, err)
+}
+
+func TestEditionRepository_FindTop10FinishTimeRecordsByTeamName(t *testing.T) {
+	edition := importTestEdition(t, openTestStore(t), 96)
+
+	assert.Equal(t, []hakone.Runner{"山田太郎", "鈴木次郎"}, runners(edition.FindTop10FinishTimeRecordsByTeamName("東洋大学")))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runners(edition.FindTop10FinishTimeRecordsByTeamName("東京国際大学")))
+	assert.Equal(t, 0, len(edition.FindTop10FinishTimeRecordsByTeamName("箱根大学")))
+	assert.Nil(t, edition.Err())
+}
+
+func TestEditionRepository_FindRunnersByTeamName(t *testing.T) {
+	edition := importTestEdition(t, openTestStore(t), 96)
+
+	assert.Equal(t, []hakone.Runner{"山田太郎", "鈴木次郎", "佐藤三郎", "田中四郎"}, runners(edition.FindRunnersByTeamName("東洋大")))
+}
+
+func TestStandingsService_EditionRepository(t *testing.T) {
+	edition := importTestEdition(t, openTestStore(t), 96)
+	service := usecase.StandingsService{TeamRepository: edition, Top10Repository: edition, Edition: hakone.NewEdition(96)}
+
+	standings, err := service.CalculateStandings()
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(standings.Teams))
+	if len(standings.Teams) != 2 {
+		return
+	}
+	assert.Equal(t, "東洋大学", standings.Teams[0].Team.Name)
+	assert.Equal(t, "東京国際大学", standings.Teams[1].Team.Name)
+}
+
+func teamIds(teams []hakone.Team) []int {
+	ids := make([]int, len(teams))
+	for i, team := range teams {
+		ids[i] = team.Id
+	}
+	return ids
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"database/sql"
+	"github.com/pkg/errors"
+	_ "modernc.org/sqlite"
+)
+
+// Store keeps teams and results of every edition in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the database file and applies migrations which are not applied yet, ":memory:" opens an in-memory one.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open database %s", path)
+	}
+	// an in-memory database lives only in its connection.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "failed to enable foreign keys")
+	}
+	store := Store{db: db}
+	if err := store.migrate(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &store, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) transaction(run func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := run(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpen_Migrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hakone.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := store.Version()
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations), version)
+	_ = store.Close()
+
+	store, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	version, err = store.Version()
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations), version)
+}
+
+func TestOpen_NewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hakone.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.db.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, len(migrations)+1)
+	assert.Nil(t, err)
+	_ = store.Close()
+
+	_, err = Open(path)
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,39 @@
 package hakone
 
+// Team has the canonical name in Name, ShortName is the name printed on result sheets like "東洋大" and Reading is
+// the reading of Name in hiragana.
+// Id is the same across editions, and Edition is the edition the team entered, zero when it is not about an edition.
 type Team struct {
-	Id   int    `json:"team_id"`
-	Name string `json:"name"`
+	Id          int      `json:"team_id"`
+	Edition     int      `json:"edition,omitempty"`
+	Name        string   `json:"name"`
+	ShortName   string   `json:"short_name,omitempty"`
+	Reading     string   `json:"reading,omitempty"`
+	EnglishName string   `json:"english_name,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+// RecordName returns the team name used in records.
+func (t Team) RecordName() TeamName {
+	if t.ShortName != "" {
+		return TeamName(t.ShortName)
+	}
+	return TeamName(t.Name)
+}
+
+// Names returns every name of the team which is not empty.
+func (t Team) Names() []string {
+	names := make([]string, 0, 3+len(t.Aliases))
+	for _, name := range append([]string{t.Name, t.ShortName, t.EnglishName}, t.Aliases...) {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// InEdition returns the team as an entry of the edition.
+func (t Team) InEdition(edition int) Team {
+	t.Edition = edition
+	return t
 }
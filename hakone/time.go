@@ -0,0 +1,213 @@
+package hakone
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Millisecond Time = 1
+	Second           = 1000 * Millisecond
+	Minute           = 60 * Second
+	Hour             = 60 * Minute
+)
+
+func Seconds(s int) Time {
+	return Time(s) * Second
+}
+
+func Minutes(m int) Time {
+	return Time(m) * Minute
+}
+
+func Hours(h int) Time {
+	return Time(h) * Hour
+}
+
+// HalfMarathon is the distance of the qualification race in km.
+const HalfMarathon = 21.0975
+
+// FromDuration converts the duration truncating less than a millisecond.
+func FromDuration(d time.Duration) Time {
+	return Time(d / time.Millisecond)
+}
+
+func (t Time) Duration() time.Duration {
+	return time.Duration(t) * time.Millisecond
+}
+
+func (t Time) Add(other Time) Time {
+	return t + other
+}
+
+func (t Time) Sub(other Time) Time {
+	return t - other
+}
+
+func (t Time) Mul(n int) Time {
+	return t * Time(n)
+}
+
+// Div divides the time rounding to the nearest millisecond.
+func (t Time) Div(n int) Time {
+	return Time(math.Round(float64(t) / float64(n)))
+}
+
+func (t Time) Before(other Time) bool {
+	return t < other
+}
+
+func (t Time) After(other Time) bool {
+	return t > other
+}
+
+// Compare returns -1 if t is faster than other, 1 if slower and 0 if same.
+func (t Time) Compare(other Time) int {
+	switch {
+	case t < other:
+		return -1
+	case t > other:
+		return 1
+	}
+	return 0
+}
+
+// Pace returns the time per km for the distance in km.
+func (t Time) Pace(distance float64) Time {
+	if distance <= 0 {
+		return 0
+	}
+	return Time(math.Round(float64(t) / distance))
+}
+
+func Sum(times []Time) Time {
+	var sum Time
+	for _, t := range times {
+		sum += t
+	}
+	return sum
+}
+
+// Average returns zero for empty times.
+func Average(times []Time) Time {
+	if len(times) == 0 {
+		return 0
+	}
+	return Sum(times).Div(len(times))
+}
+
+// String formats the time in the official notation, fraction digits are shown only when the time has them.
+func (t Time) String() string {
+	digits := 0
+	for fraction := int(t % Second); fraction != 0 && digits < 3; fraction = (fraction * 10) % 1000 {
+		digits++
+	}
+	return t.Notation(digits)
+}
+
+// Seconds returns the time in seconds with fraction.
+func (t Time) Seconds() float64 {
+	return float64(t) / float64(Second)
+}
+
+// WholeSeconds returns the time in seconds truncating the fraction.
+func (t Time) WholeSeconds() int {
+	return int(t / Second)
+}
+
+func (t Time) HasFraction() bool {
+	return t%Second != 0
+}
+
+// Notation formats the time in the official notation like "1:02:03" or "59:52", with the given number of
+// fraction digits (0 to 3), the fraction is truncated as official results do.
+func (t Time) Notation(digits int) string {
+	if digits < 0 {
+		digits = 0
+	} else if digits > 3 {
+		digits = 3
+	}
+	sign := ""
+	value := t
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	hours := value / Hour
+	minutes := (value % Hour) / Minute
+	seconds := (value % Minute) / Second
+	var buf strings.Builder
+	buf.WriteString(sign)
+	if hours > 0 {
+		buf.WriteString(fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds))
+	} else {
+		buf.WriteString(fmt.Sprintf("%d:%02d", minutes, seconds))
+	}
+	if digits > 0 {
+		fraction := int(value%Second) / int(math.Pow10(3-digits))
+		buf.WriteString(fmt.Sprintf(".%0*d", digits, fraction))
+	}
+	return buf.String()
+}
+
+// DecimalSeconds formats the time in seconds like "3723" or "3723.040", fraction digits are shown only when the time
+// has them.
+func (t Time) DecimalSeconds() string {
+	if !t.HasFraction() {
+		return strconv.Itoa(t.WholeSeconds())
+	}
+	sign, value := "", t
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	return fmt.Sprintf("%s%d.%03d", sign, value.WholeSeconds(), int(value%Second))
+}
+
+// MarshalJSON encodes the time in whole seconds truncating the fraction, which readers of integer seconds can decode.
+// Record keeps the fraction in separate fields, see Record.MarshalJSON.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.WholeSeconds())
+}
+
+// UnmarshalJSON decodes seconds with or without fraction, fractions are accepted for hand-written files like
+// corrections.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	text := string(bytes.TrimSpace(b))
+	if text == "null" {
+		return nil
+	}
+	var seconds json.Number
+	if err := json.Unmarshal(b, &seconds); err != nil {
+		return errors.Wrapf(err, "time should be number of seconds: %s", text)
+	}
+	whole := seconds.String()
+	fraction := ""
+	if index := strings.Index(whole, "."); index >= 0 {
+		fraction = whole[index+1:]
+		whole = whole[:index]
+	}
+	if strings.ContainsAny(whole, "eE") || strings.ContainsAny(fraction, "eE") {
+		return errors.New(fmt.Sprintf("time should not be exponential form: %s", text))
+	}
+	negative := strings.HasPrefix(whole, "-")
+	sec, err := strconv.Atoi(strings.TrimPrefix(whole, "-"))
+	if err != nil {
+		return errors.Wrapf(err, "invalid time: %s", text)
+	}
+	millis, err := parseFraction(fraction)
+	if err != nil {
+		return errors.Wrapf(err, "invalid time: %s", text)
+	}
+	result := Seconds(sec).plus(millis)
+	if negative {
+		result = -result
+	}
+	*t = result
+	return nil
+}
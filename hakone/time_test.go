@@ -0,0 +1,133 @@
+package hakone
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTime_Notation(t *testing.T) {
+	time := Hours(1) + Minutes(2) + Seconds(3) + 456*Millisecond
+	assert.Equal(t, "1:02:03", time.Notation(0))
+	assert.Equal(t, "1:02:03.4", time.Notation(1))
+	assert.Equal(t, "1:02:03.45", time.Notation(2))
+	assert.Equal(t, "59:52", Seconds(59*60+52).Notation(0))
+	assert.Equal(t, "-0:30.0", (-Seconds(30)).Notation(1))
+}
+
+func TestTime_MarshalJSON_WholeSeconds(t *testing.T) {
+	bytes, err := json.Marshal(Seconds(3723))
+	assert.Nil(t, err)
+	assert.Equal(t, "3723", string(bytes))
+}
+
+func TestTime_MarshalJSON_Fraction(t *testing.T) {
+	bytes, err := json.Marshal(Seconds(3723) + 40*Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, "3723", string(bytes))
+}
+
+func TestTime_DecimalSeconds(t *testing.T) {
+	assert.Equal(t, "3723", Seconds(3723).DecimalSeconds())
+	assert.Equal(t, "3723.040", (Seconds(3723) + 40*Millisecond).DecimalSeconds())
+	assert.Equal(t, "-0.500", (-500 * Millisecond).DecimalSeconds())
+	assert.Equal(t, "-1.500", (-1500 * Millisecond).DecimalSeconds())
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	var time Time
+	assert.Nil(t, json.Unmarshal([]byte("3723"), &time))
+	assert.Equal(t, Seconds(3723), time)
+	assert.Nil(t, json.Unmarshal([]byte("3723.4"), &time))
+	assert.Equal(t, Seconds(3723)+400*Millisecond, time)
+	assert.Nil(t, json.Unmarshal([]byte("-30"), &time))
+	assert.Equal(t, -Seconds(30), time)
+	assert.NotNil(t, json.Unmarshal([]byte(`"1:02:03"`), &time))
+	assert.NotNil(t, json.Unmarshal([]byte("1e3"), &time))
+}
+
+func TestRecord_JSON_Version1(t *testing.T) {
+	var record Record
+	err := json.Unmarshal([]byte(`{"order":1,"time_of_5_km":900,"finish_time":3723}`), &record)
+	assert.Nil(t, err)
+	assert.Equal(t, Seconds(900), record.TimeOf5km)
+	assert.Equal(t, Seconds(3723), record.FinishTime)
+}
+
+func TestRecord_JSON_Version2(t *testing.T) {
+	record := Record{
+		Order:            1,
+		TimeOf5km:        Seconds(900),
+		FinishTime:       Seconds(3723) + 40*Millisecond,
+		RapFrom5kmTo10km: -500 * Millisecond,
+	}
+	bytes, err := json.Marshal(record)
+	assert.Nil(t, err)
+
+	var fields map[string]interface{}
+	assert.Nil(t, json.Unmarshal(bytes, &fields))
+	assert.Equal(t, float64(900), fields["time_of_5_km"])
+	assert.Nil(t, fields["time_of_5_km_ms"])
+	assert.Equal(t, float64(3723), fields["finish_time"])
+	assert.Equal(t, float64(3723040), fields["finish_time_ms"])
+	assert.Equal(t, float64(0), fields["rap_5_to_10"])
+	assert.Equal(t, float64(-500), fields["rap_5_to_10_ms"])
+
+	var decoded Record
+	assert.Nil(t, json.Unmarshal(bytes, &decoded))
+	assert.Equal(t, record, decoded)
+}
+
+func TestRecord_JSON_Version1Reader(t *testing.T) {
+	bytes, err := json.Marshal(Record{Order: 1, FinishTime: Seconds(3723) + 400*Millisecond})
+	assert.Nil(t, err)
+	var version1 struct {
+		Order      int `json:"order"`
+		FinishTime int `json:"finish_time"`
+	}
+	assert.Nil(t, json.Unmarshal(bytes, &version1))
+	assert.Equal(t, 3723, version1.FinishTime)
+}
+
+func TestTime_String(t *testing.T) {
+	assert.Equal(t, "1:02:03", (Hours(1) + Minutes(2) + Seconds(3)).String())
+	assert.Equal(t, "59:52", Seconds(59*60+52).String())
+	assert.Equal(t, "14:47.3", (Seconds(14*60+47) + 300*Millisecond).String())
+	assert.Equal(t, "14:47.05", (Seconds(14*60+47) + 50*Millisecond).String())
+	assert.Equal(t, "0:00", Time(0).String())
+}
+
+func TestTime_Pace(t *testing.T) {
+	assert.Equal(t, Seconds(3*60), Seconds(15*60).Pace(5))
+	assert.Equal(t, Time(0), Seconds(15*60).Pace(0))
+	assert.Equal(t, "2:56", Seconds(62*60).Pace(HalfMarathon).Notation(0))
+}
+
+func TestTime_Arithmetic(t *testing.T) {
+	a := Seconds(100)
+	b := Seconds(40)
+	assert.Equal(t, Seconds(140), a.Add(b))
+	assert.Equal(t, Seconds(60), a.Sub(b))
+	assert.Equal(t, Seconds(300), a.Mul(3))
+	assert.Equal(t, Time(33333), a.Div(3))
+	assert.Equal(t, Seconds(70), Average([]Time{a, b}))
+	assert.Equal(t, Time(0), Average([]Time{}))
+	assert.Equal(t, Seconds(140), Sum([]Time{a, b}))
+}
+
+func TestTime_Compare(t *testing.T) {
+	a := Seconds(100)
+	b := Seconds(40)
+	assert.True(t, b.Before(a))
+	assert.True(t, a.After(b))
+	assert.Equal(t, 1, a.Compare(b))
+	assert.Equal(t, -1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(Seconds(100)))
+}
+
+func TestTime_Duration(t *testing.T) {
+	d := Seconds(90).Duration()
+	assert.Equal(t, 90*time.Second, d)
+	assert.Equal(t, Seconds(90)+5*Millisecond, FromDuration(90*time.Second+5*time.Millisecond+300*time.Microsecond))
+}
@@ -7,3 +7,5 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/testify v1.4.0
 )
+
+replace github.com/mike-neck/go-hakone-qualification/hakone => ../
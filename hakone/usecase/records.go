@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
 	"sort"
 )
 
@@ -9,9 +10,16 @@ type Top10RecordsRepository interface {
 	FindTop10FinishTimeRecordsByTeamName(name hakone.TeamName) []hakone.Record
 }
 
+// RecordService ranks records by TimeBasis, the gun time by default.
+// Top10RecordsRepository is expected to return records ordered by the gun time, so on the net time basis every record
+// is read from RunnerRepository, or from Top10Repository when it implements RunnerRepository, and the net time basis
+// fails without them. It fails also when no record has the net time, layouts without the net time column like
+// the default one do not fill it. A record without the net time is ranked by the gun time.
 type RecordService struct {
-	TeamRepository  TeamRepository
-	Top10Repository Top10RecordsRepository
+	TeamRepository   TeamRepository
+	Top10Repository  Top10RecordsRepository
+	RunnerRepository RunnerRepository
+	TimeBasis        hakone.TimeBasis
 }
 
 type PersonalRecord struct {
@@ -26,14 +34,64 @@ type TeamRecords struct {
 	Records []PersonalRecord
 }
 
+// TopRecords returns records of the team limited to the given number of runners.
+func (tr *TeamRecords) TopRecords(size int) TeamRecords {
+	records := tr.Records
+	if len(records) > size {
+		records = records[:size]
+	}
+	return TeamRecords{Team: tr.Team, Records: records}
+}
+
+func (tr *TeamRecords) Total() hakone.Time {
+	var total hakone.Time
+	for _, record := range tr.Records {
+		total += record.Time
+	}
+	return total
+}
+
+// CountByGrade counts runners of the team by their academic year.
+func (tr *TeamRecords) CountByGrade() map[hakone.Grade]int {
+	counts := make(map[hakone.Grade]int)
+	for _, record := range tr.Records {
+		counts[record.Grade]++
+	}
+	return counts
+}
+
 type Top10RecordsByTeam struct {
 	Records []TeamRecords
 }
 
-func (rs *RecordService) FindTop10RecordsByNames(names []hakone.TeamName) Top10RecordsByTeam {
+// CountByGrade counts runners in the top 10 of each team by their academic year.
+func (t *Top10RecordsByTeam) CountByGrade() map[string]map[hakone.Grade]int {
+	counts := make(map[string]map[hakone.Grade]int)
+	for _, teamRecords := range t.Records {
+		counts[teamRecords.Team.Name] = teamRecords.CountByGrade()
+	}
+	return counts
+}
+
+func (rs *RecordService) FindTop10RecordsByNames(names []hakone.TeamName) (Top10RecordsByTeam, error) {
 	teamSize := len(names)
 	if teamSize == 0 {
-		return Top10RecordsByTeam{}
+		return Top10RecordsByTeam{}, nil
+	}
+	basis := rs.TimeBasis
+	runners, byRunners := rs.runnerRepository()
+	rank := func(record hakone.Record) int {
+		return record.Order
+	}
+	if basis == hakone.NetTime {
+		if !byRunners {
+			return Top10RecordsByTeam{}, errors.New("net time basis needs RunnerRepository to rank runners among all")
+		}
+		all := runners.ListAllRunners()
+		if !hasNetTime(all) {
+			return Top10RecordsByTeam{}, errors.New("no record has the net time")
+		}
+		rank = rankAmongAll(all, basis)
 	}
 	teamRecords := make([]TeamRecords, 0)
 	for _, name := range names {
@@ -41,17 +99,30 @@ func (rs *RecordService) FindTop10RecordsByNames(names []hakone.TeamName) Top10R
 		if err != nil {
 			continue
 		}
-		personalRecords := make([]PersonalRecord, 10)
-		records := rs.Top10Repository.FindTop10FinishTimeRecordsByTeamName(name)
-		sort.Slice(records, func(i, j int) bool {
+		var records []hakone.Record
+		if byRunners {
+			records = scoredRecords(runners.FindRunnersByTeamName(team.RecordName()))
+		} else {
+			records = scoredRecords(rs.Top10Repository.FindTop10FinishTimeRecordsByTeamName(team.RecordName()))
+		}
+		sort.SliceStable(records, func(i, j int) bool {
+			ti := records[i].FinishTimeOf(basis)
+			tj := records[j].FinishTimeOf(basis)
+			if ti != tj {
+				return ti < tj
+			}
 			return records[i].Order < records[j].Order
 		})
+		if len(records) > 10 {
+			records = records[:10]
+		}
+		personalRecords := make([]PersonalRecord, len(records))
 		for index, record := range records {
 			personalRecords[index] = PersonalRecord{
-				RankAmongAll:  record.Order,
+				RankAmongAll:  rank(record),
 				RankAmongTeam: index + 1,
 				Grade:         record.Grade,
-				Time:          record.FinishTime,
+				Time:          record.FinishTimeOf(basis),
 			}
 		}
 		teamRecords = append(teamRecords, TeamRecords{
@@ -59,5 +130,59 @@ func (rs *RecordService) FindTop10RecordsByNames(names []hakone.TeamName) Top10R
 			Records: personalRecords,
 		})
 	}
-	return Top10RecordsByTeam{Records: teamRecords}
+	return Top10RecordsByTeam{Records: teamRecords}, nil
+}
+
+// runnerRepository returns the repository to read every record, it is used only on the net time basis.
+func (rs *RecordService) runnerRepository() (RunnerRepository, bool) {
+	if rs.TimeBasis != hakone.NetTime {
+		return nil, false
+	}
+	if rs.RunnerRepository != nil {
+		return rs.RunnerRepository, true
+	}
+	runners, ok := rs.Top10Repository.(RunnerRepository)
+	return runners, ok
+}
+
+// rankAmongAll ranks records among scored records of all runners on the basis, runners with the same time share
+// the same rank.
+func rankAmongAll(all []hakone.Record, basis hakone.TimeBasis) func(record hakone.Record) int {
+	times := make([]hakone.Time, 0, len(all))
+	for _, record := range all {
+		if record.IsScored() {
+			times = append(times, record.FinishTimeOf(basis))
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+	return func(record hakone.Record) int {
+		time := record.FinishTimeOf(basis)
+		return sort.Search(len(times), func(i int) bool {
+			return times[i] >= time
+		}) + 1
+	}
+}
+
+func hasNetTime(records []hakone.Record) bool {
+	for _, record := range records {
+		if record.NetTime > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// scoredRecords drops non-finishers, open entries and zero-value records which repositories use to pad teams
+// with less than 10 finishers.
+func scoredRecords(records []hakone.Record) []hakone.Record {
+	result := make([]hakone.Record, 0, len(records))
+	for _, record := range records {
+		if record.Order == 0 || !record.IsScored() {
+			continue
+		}
+		result = append(result, record)
+	}
+	return result
 }
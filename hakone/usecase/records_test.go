@@ -15,9 +15,9 @@ func newRecord(order int, name, team string, grade, hour, sec int) hakone.Record
 	return hakone.Record{
 		Order:      order,
 		Runner:     hakone.Runner(name),
-		Grade:      hakone.Grade(fmt.Sprintf("(%d)", grade)),
+		Grade:      hakone.Grade(grade),
 		Team:       hakone.TeamName(team),
-		FinishTime: hakone.Time(hour*60*60 + sec),
+		FinishTime: hakone.Seconds(hour*60*60 + sec),
 	}
 }
 
@@ -43,7 +43,8 @@ func TestRecordService_FindTop10RecordsByNames(t *testing.T) {
 		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
 	}
 
-	result := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大", "日本体育大"})
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大", "日本体育大"})
+	assert.Nil(t, err)
 
 	recs := result.Records
 	assert.Equal(t, 2, len(recs))
@@ -87,8 +88,121 @@ func TestRecordService_FindTop10RecordsByNames_WithUnknownTeam(t *testing.T) {
 		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
 	}
 
-	result := service.FindTop10RecordsByNames([]hakone.TeamName{"筑波大", "鹿屋体育大"})
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"筑波大", "鹿屋体育大"})
+	assert.Nil(t, err)
 
 	recs := result.Records
 	assert.Equal(t, 0, len(recs))
 }
+
+func TestRecordService_FindTop10RecordsByNames_ExcludesNonFinishers(t *testing.T) {
+	records := makeRecords()
+	dnf := newRecord(2, "東洋大ランナー-DNF", "東洋大", 1, 0, 0)
+	dnf.Status = hakone.StatusDNF
+	open := newRecord(3, "東洋大ランナー-OPEN", "東洋大", 1, 1, 70)
+	open.Status = hakone.StatusOpen
+	records = append([]hakone.Record{dnf, open}, records...)
+	service := RecordService{
+		TeamRepository:  listTeamsTestRepository,
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
+	}
+
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大"})
+	assert.Nil(t, err)
+
+	recs := result.Records
+	assert.Equal(t, 1, len(recs))
+	if len(recs) != 1 {
+		return
+	}
+	assert.Equal(t, 8, len(recs[0].Records))
+	assert.Equal(t, 1, recs[0].Records[0].RankAmongAll)
+	assert.Equal(t, 16, recs[0].Records[1].RankAmongAll)
+}
+
+func TestTop10RecordsByTeam_CountByGrade(t *testing.T) {
+	records := makeRecords()
+	service := RecordService{
+		TeamRepository:  listTeamsTestRepository,
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
+	}
+
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大", "日本体育大"})
+	assert.Nil(t, err)
+	counts := result.CountByGrade()
+
+	assert.Equal(t, 2, len(counts))
+	// grades of top 10 are 1,2,3,4,1,2,3,4,1,2
+	assert.Equal(t, 3, counts["東洋大"][1])
+	assert.Equal(t, 3, counts["東洋大"][2])
+	assert.Equal(t, 2, counts["東洋大"][3])
+	assert.Equal(t, 2, counts["東洋大"][4])
+	assert.Equal(t, 3, counts["日本体育大"][hakone.Grade(1)])
+}
+
+func TestRecordService_FindTop10RecordsByNames_NetTime(t *testing.T) {
+	records := makeRecords()
+	// 2nd runner of 東洋大 is faster than the 1st on the net time
+	records[3].NetTime = records[0].FinishTime - hakone.Seconds(1)
+	service := RecordService{
+		TeamRepository:   listTeamsTestRepository,
+		Top10Repository:  &Top10RecordsRepoTestImpl{Records: records},
+		RunnerRepository: &InMemoryRunnerRepository{Records: records},
+		TimeBasis:        hakone.NetTime,
+	}
+
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大"})
+	assert.Nil(t, err)
+
+	recs := result.Records
+	assert.Equal(t, 1, len(recs))
+	if len(recs) != 1 {
+		return
+	}
+	assert.Equal(t, 1, recs[0].Records[0].RankAmongAll)
+	assert.Equal(t, records[0].FinishTime-hakone.Seconds(1), recs[0].Records[0].Time)
+	assert.Equal(t, 2, recs[0].Records[1].RankAmongAll)
+}
+
+func TestRecordService_FindTop10RecordsByNames_NetTimeErrors(t *testing.T) {
+	records := makeRecords()
+	service := RecordService{
+		TeamRepository:   listTeamsTestRepository,
+		Top10Repository:  &Top10RecordsRepoTestImpl{Records: records},
+		RunnerRepository: &InMemoryRunnerRepository{Records: records},
+		TimeBasis:        hakone.NetTime,
+	}
+	_, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大"})
+	assert.NotNil(t, err)
+
+	records[3].NetTime = records[0].FinishTime - hakone.Seconds(1)
+	service.RunnerRepository = nil
+	_, err = service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大"})
+	assert.NotNil(t, err)
+}
+
+func TestRecordService_FindTop10RecordsByNames_NetTimeOutOfGunTop10(t *testing.T) {
+	records := makeRecords()
+	// 11th runner of 東洋大 on the gun time is the fastest of all on the net time
+	records[30].NetTime = records[0].FinishTime - hakone.Seconds(1)
+	service := RecordService{
+		TeamRepository:   listTeamsTestRepository,
+		Top10Repository:  &Top10RecordsRepoTestImpl{Records: records},
+		RunnerRepository: &InMemoryRunnerRepository{Records: records},
+		TimeBasis:        hakone.NetTime,
+	}
+
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大"})
+	assert.Nil(t, err)
+
+	recs := result.Records
+	assert.Equal(t, 1, len(recs))
+	if len(recs) != 1 {
+		return
+	}
+	assert.Equal(t, 10, len(recs[0].Records))
+	assert.Equal(t, 1, recs[0].Records[0].RankAmongAll)
+	assert.Equal(t, records[0].FinishTime-hakone.Seconds(1), recs[0].Records[0].Time)
+	assert.Equal(t, 2, recs[0].Records[1].RankAmongAll)
+	assert.Equal(t, records[24].FinishTime, recs[0].Records[9].Time)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/mike-neck/go-hakone-qualification/hakone/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	_ TeamRepository         = (*repository.JsonlRepository)(nil)
+	_ Top10RecordsRepository = (*repository.JsonlRepository)(nil)
+	_ RunnerRepository       = (*repository.JsonlRepository)(nil)
+)
+
+func TestStandingsService_JsonlRepository(t *testing.T) {
+	repo, err := repository.Load("../repository/testdata/teams.jsonl", "../repository/testdata/personal.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := StandingsService{TeamRepository: repo, Top10Repository: repo, Edition: hakone.NewEdition(96)}
+
+	standings, err := service.CalculateStandings()
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(standings.Teams))
+	if len(standings.Teams) != 2 {
+		return
+	}
+	assert.Equal(t, "東洋大学", standings.Teams[0].Team.Name)
+	assert.Equal(t, 2, len(standings.Teams[0].Records))
+	assert.Equal(t, "東京国際大学", standings.Teams[1].Team.Name)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"sort"
+	"strings"
+)
+
+type RunnerRepository interface {
+	ListAllRunners() []hakone.Record
+	FindRunnersByTeamName(name hakone.TeamName) []hakone.Record
+}
+
+// InMemoryRunnerRepository is a RunnerRepository holding records of the personal result.
+type InMemoryRunnerRepository struct {
+	Records []hakone.Record
+}
+
+func (r *InMemoryRunnerRepository) ListAllRunners() []hakone.Record {
+	result := make([]hakone.Record, len(r.Records))
+	copy(result, r.Records)
+	return result
+}
+
+func (r *InMemoryRunnerRepository) FindRunnersByTeamName(name hakone.TeamName) []hakone.Record {
+	result := make([]hakone.Record, 0)
+	for _, record := range r.Records {
+		if record.Team == name {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+// RunnerService finds runners, results are ordered by the official place and runners without place follow them.
+// TeamRepository is optional and resolves aliases of team names.
+type RunnerService struct {
+	Repository     RunnerRepository
+	TeamRepository TeamRepository
+}
+
+// FindRunnersByName finds runners whose name or romanized name contains the name, width, case, spaces and the
+// difference between hiragana and katakana are ignored.
+func (rs *RunnerService) FindRunnersByName(name string) []hakone.Record {
+	query := hakone.NormalizeRunnerName(name)
+	if query == "" {
+		return make([]hakone.Record, 0)
+	}
+	return rs.filter(func(record hakone.Record) bool {
+		return strings.Contains(hakone.NormalizeRunnerName(string(record.Runner)), query) ||
+			strings.Contains(hakone.NormalizeRunnerName(record.RomanizedName), query)
+	})
+}
+
+func (rs *RunnerService) FindRunnersByTeam(name string) []hakone.Record {
+	teamName := hakone.TeamName(name)
+	if rs.TeamRepository != nil {
+		if team, err := rs.TeamRepository.FindTeamByName(name); err == nil {
+			teamName = team.RecordName()
+		}
+	}
+	return sortByPlace(rs.Repository.FindRunnersByTeamName(teamName))
+}
+
+func (rs *RunnerService) FindRunnersByGrade(grade hakone.Grade) []hakone.Record {
+	return rs.filter(func(record hakone.Record) bool {
+		return record.Grade == grade
+	})
+}
+
+// FindRunnersByPlaceRange finds runners whose official place is between from and to inclusive.
+func (rs *RunnerService) FindRunnersByPlaceRange(from, to int) []hakone.Record {
+	return rs.filter(func(record hakone.Record) bool {
+		return record.Place > 0 && from <= record.Place && record.Place <= to
+	})
+}
+
+func (rs *RunnerService) filter(predicate func(hakone.Record) bool) []hakone.Record {
+	result := make([]hakone.Record, 0)
+	for _, record := range rs.Repository.ListAllRunners() {
+		if predicate(record) {
+			result = append(result, record)
+		}
+	}
+	return sortByPlace(result)
+}
+
+func sortByPlace(records []hakone.Record) []hakone.Record {
+	sort.SliceStable(records, func(i, j int) bool {
+		pi := records[i].Place
+		pj := records[j].Place
+		if pi == 0 || pj == 0 {
+			return pi != 0 && pj == 0
+		}
+		return pi < pj
+	})
+	return records
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var runnersTestRepository = &InMemoryRunnerRepository{
+	Records: []hakone.Record{
+		{Place: 3, Runner: "山田　太郎", Grade: 3, Team: "東洋大"},
+		{Place: 1, Runner: "ムセンビ", RomanizedName: "MUSEMBI", Grade: 2, Team: "東京国際大"},
+		{Place: 0, Runner: "鈴木次郎", Grade: 1, Team: "東洋大", Status: hakone.StatusDNS},
+		{Place: 2, Runner: "佐藤三郎", Grade: 3, Team: "東海大"},
+		{Place: 5, Runner: "山田次郎", Grade: 1, Team: "東洋大"},
+	},
+}
+
+func runnerNames(records []hakone.Record) []hakone.Runner {
+	names := make([]hakone.Runner, len(records))
+	for i, record := range records {
+		names[i] = record.Runner
+	}
+	return names
+}
+
+func TestRunnerService_FindRunnersByName(t *testing.T) {
+	service := RunnerService{Repository: runnersTestRepository}
+
+	assert.Equal(t, []hakone.Runner{"山田　太郎", "山田次郎"}, runnerNames(service.FindRunnersByName("山田")))
+	assert.Equal(t, []hakone.Runner{"山田　太郎"}, runnerNames(service.FindRunnersByName("山田太郎")))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runnerNames(service.FindRunnersByName("むせんび")))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runnerNames(service.FindRunnersByName("ｾﾝﾋﾞ")))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runnerNames(service.FindRunnersByName("musembi")))
+	assert.Empty(t, service.FindRunnersByName(""))
+}
+
+func TestRunnerService_FindRunnersByTeam(t *testing.T) {
+	service := RunnerService{Repository: runnersTestRepository}
+
+	assert.Equal(t, []hakone.Runner{"山田　太郎", "山田次郎", "鈴木次郎"}, runnerNames(service.FindRunnersByTeam("東洋大")))
+	assert.Empty(t, service.FindRunnersByTeam("東洋大学"))
+}
+
+func TestRunnerService_FindRunnersByTeam_Alias(t *testing.T) {
+	service := RunnerService{
+		Repository:     runnersTestRepository,
+		TeamRepository: &RegistryTeamRepository{Registry: hakone.DefaultTeamRegistry()},
+	}
+
+	assert.Equal(t, []hakone.Runner{"山田　太郎", "山田次郎", "鈴木次郎"}, runnerNames(service.FindRunnersByTeam("東洋大学")))
+	assert.Equal(t, []hakone.Runner{"ムセンビ"}, runnerNames(service.FindRunnersByTeam("Tokyo Kokusai Univ")))
+}
+
+func TestRunnerService_FindRunnersByGrade(t *testing.T) {
+	service := RunnerService{Repository: runnersTestRepository}
+
+	assert.Equal(t, []hakone.Runner{"山田次郎", "鈴木次郎"}, runnerNames(service.FindRunnersByGrade(1)))
+}
+
+func TestRunnerService_FindRunnersByPlaceRange(t *testing.T) {
+	service := RunnerService{Repository: runnersTestRepository}
+
+	assert.Equal(t, []hakone.Runner{"ムセンビ", "佐藤三郎", "山田　太郎"}, runnerNames(service.FindRunnersByPlaceRange(1, 3)))
+	assert.Equal(t, []hakone.Runner{"山田次郎"}, runnerNames(service.FindRunnersByPlaceRange(4, 10)))
+	assert.Empty(t, service.FindRunnersByPlaceRange(0, 0))
+}
+
+func TestInMemoryRunnerRepository_ListAllRunners(t *testing.T) {
+	runners := runnersTestRepository.ListAllRunners()
+	runners[0].Runner = "changed"
+
+	assert.Equal(t, hakone.Runner("山田　太郎"), runnersTestRepository.Records[0].Runner)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"sort"
+	"strings"
+)
+
+// TeamMatch is a team found by SearchTeams, higher score is better match.
+type TeamMatch struct {
+	Team  hakone.Team
+	Score int
+}
+
+// base scores of each kind of names, prefix and substring matches are lower than exact matches.
+const (
+	nameScore    = 100
+	readingScore = 90
+	romajiScore  = 80
+	prefixLoss   = 10
+	partialLoss  = 20
+)
+
+// SearchTeams finds teams by names, aliases, readings in kana and romanization. Width, case and spaces are ignored,
+// results are ordered by the score and the id.
+func (ts *TeamService) SearchTeams(query string) []TeamMatch {
+	name := hakone.NormalizeTeamName(query)
+	if name == "" {
+		return make([]TeamMatch, 0)
+	}
+	reading := hakone.ToHiragana(name)
+	romaji := ""
+	if hakone.IsRomaji(query) {
+		romaji = hakone.NormalizeRomaji(query)
+	}
+
+	matches := make([]TeamMatch, 0)
+	for _, team := range ts.Repository.ListAllTeams() {
+		score := 0
+		for _, n := range team.Names() {
+			score = maxScore(score, matchScore(hakone.NormalizeTeamName(n), name, nameScore))
+			if romaji != "" {
+				score = maxScore(score, matchScore(hakone.NormalizeRomaji(n), romaji, romajiScore))
+			}
+		}
+		if team.Reading != "" {
+			score = maxScore(score, matchScore(hakone.ToHiragana(team.Reading), reading, readingScore))
+			if romaji != "" {
+				score = maxScore(score, matchScore(hakone.NormalizeRomaji(hakone.Romanize(team.Reading)), romaji, romajiScore))
+			}
+		}
+		if score > 0 {
+			matches = append(matches, TeamMatch{Team: team, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Team.Id < matches[j].Team.Id
+	})
+	return matches
+}
+
+func matchScore(target, query string, base int) int {
+	switch {
+	case target == "" || query == "":
+		return 0
+	case target == query:
+		return base
+	case strings.HasPrefix(target, query):
+		return base - prefixLoss
+	case strings.Contains(target, query):
+		return base - partialLoss
+	}
+	return 0
+}
+
+func maxScore(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func searchTeamIds(matches []TeamMatch) []int {
+	ids := make([]int, len(matches))
+	for i, match := range matches {
+		ids[i] = match.Team.Id
+	}
+	return ids
+}
+
+func TestTeamService_SearchTeams(t *testing.T) {
+	service := TeamService{Repository: &RegistryTeamRepository{Registry: hakone.DefaultTeamRegistry()}}
+
+	for _, query := range []string{"東洋大学", "東洋大", "とうよう", "トウヨウ", "ﾄｳﾖｳ", "Toyo", "ｔｏｙｏ", "touyou"} {
+		matches := service.SearchTeams(query)
+		if assert.NotEmpty(t, matches, query) {
+			assert.Equal(t, 2, matches[0].Team.Id, query)
+		}
+	}
+}
+
+func TestTeamService_SearchTeams_Score(t *testing.T) {
+	service := TeamService{Repository: &RegistryTeamRepository{Registry: hakone.DefaultTeamRegistry()}}
+
+	matches := service.SearchTeams("日本")
+
+	assert.Equal(t, []int{4, 5}, searchTeamIds(matches))
+	assert.Equal(t, 90, matches[0].Score)
+
+	matches = service.SearchTeams("国際")
+	assert.Equal(t, []int{6}, searchTeamIds(matches))
+	assert.Equal(t, 80, matches[0].Score)
+}
+
+func TestTeamService_SearchTeams_WithoutReadings(t *testing.T) {
+	service := TeamService{Repository: listTeamsTestRepository}
+
+	assert.Equal(t, []int{2}, searchTeamIds(service.SearchTeams("東洋大学")))
+	assert.Equal(t, []int{1, 2}, searchTeamIds(service.SearchTeams("東")))
+	assert.Empty(t, service.SearchTeams("toyo"))
+	assert.Empty(t, service.SearchTeams(" "))
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"sort"
+)
+
+// StandingsService ranks teams, RunnerRepository is used on the net time basis as RecordService does.
+type StandingsService struct {
+	TeamRepository   TeamRepository
+	Top10Repository  Top10RecordsRepository
+	RunnerRepository RunnerRepository
+	Edition          hakone.Edition
+	Bonuses          hakone.BonusTable
+	TimeBasis        hakone.TimeBasis
+}
+
+// TeamStanding has both the raw top-10 sum and the total adjusted by the bonus, ranks are decided by the adjusted one.
+type TeamStanding struct {
+	Rank      int
+	Team      hakone.Team
+	RawTotal  hakone.Time
+	Deduction hakone.Time
+	Total     hakone.Time
+	Records   []PersonalRecord
+	Scored    bool
+	Qualified bool
+}
+
+// BestRankAmongAll returns the best individual rank of the team on the time basis, which decides the order of teams
+// with same total.
+func (ts *TeamStanding) BestRankAmongAll() int {
+	if len(ts.Records) == 0 {
+		return 0
+	}
+	return ts.Records[0].RankAmongAll
+}
+
+type Standings struct {
+	Teams   []TeamStanding
+	Edition hakone.Edition
+}
+
+// CutoffLine returns the total time of the last qualified team.
+func (s *Standings) CutoffLine() (hakone.Time, bool) {
+	var cutoff *TeamStanding
+	for index := range s.Teams {
+		if s.Teams[index].Qualified {
+			cutoff = &s.Teams[index]
+		}
+	}
+	if cutoff == nil {
+		return 0, false
+	}
+	return cutoff.Total, true
+}
+
+func (s *Standings) QualifiedTeams() []TeamStanding {
+	teams := make([]TeamStanding, 0)
+	for _, team := range s.Teams {
+		if team.Qualified {
+			teams = append(teams, team)
+		}
+	}
+	return teams
+}
+
+func (ss *StandingsService) CalculateStandings() (Standings, error) {
+	teams := ss.TeamRepository.ListAllTeams()
+	names := make([]hakone.TeamName, len(teams))
+	for index, team := range teams {
+		names[index] = hakone.TeamName(team.Name)
+	}
+	return ss.CalculateStandingsByNames(names)
+}
+
+func (ss *StandingsService) CalculateStandingsByNames(names []hakone.TeamName) (Standings, error) {
+	recordService := RecordService{
+		TeamRepository:   ss.TeamRepository,
+		Top10Repository:  ss.Top10Repository,
+		RunnerRepository: ss.RunnerRepository,
+		TimeBasis:        ss.TimeBasis,
+	}
+	top10Records, err := recordService.FindTop10RecordsByNames(names)
+	if err != nil {
+		return Standings{}, err
+	}
+
+	edition := ss.Edition.WithDefaults()
+	standings := make([]TeamStanding, len(top10Records.Records))
+	for index, teamRecords := range top10Records.Records {
+		standing := newTeamStanding(teamRecords, edition.ScoredRunnersPerTeam)
+		standing.applyDeduction(ss.Bonuses.DeductionOf(standing.Team))
+		standings[index] = standing
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].isAheadOf(&standings[j])
+	})
+
+	for index := range standings {
+		standing := &standings[index]
+		if !standing.Scored {
+			continue
+		}
+		standing.Rank = index + 1
+		standing.Qualified = standing.Rank <= edition.QualifyingSlots
+	}
+	return Standings{Teams: standings, Edition: edition}, nil
+}
+
+func newTeamStanding(teamRecords TeamRecords, scoredRunners int) TeamStanding {
+	scoredRecords := teamRecords.TopRecords(scoredRunners)
+	total := scoredRecords.Total()
+	return TeamStanding{
+		Team:     teamRecords.Team,
+		RawTotal: total,
+		Total:    total,
+		Records:  scoredRecords.Records,
+		Scored:   len(scoredRecords.Records) == scoredRunners,
+	}
+}
+
+func (ts *TeamStanding) applyDeduction(deduction hakone.Time) {
+	ts.Deduction = deduction
+	ts.Total = ts.RawTotal - deduction
+}
+
+// isAheadOf orders scored teams by total time, then by the best individual place.
+// Unscored teams follow them in order of the number of finishers.
+func (ts *TeamStanding) isAheadOf(other *TeamStanding) bool {
+	if ts.Scored != other.Scored {
+		return ts.Scored
+	}
+	if !ts.Scored {
+		return len(ts.Records) > len(other.Records)
+	}
+	if ts.Total != other.Total {
+		return ts.Total < other.Total
+	}
+	return ts.BestRankAmongAll() < other.BestRankAmongAll()
+}
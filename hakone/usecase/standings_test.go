@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStandingsService_CalculateStandings(t *testing.T) {
+	records := makeRecords()
+	service := StandingsService{
+		TeamRepository:  listTeamsTestRepository,
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
+		Edition:         hakone.Edition{QualifyingSlots: 2},
+	}
+
+	standings, err := service.CalculateStandings()
+	assert.Nil(t, err)
+
+	teams := standings.Teams
+	assert.Equal(t, 5, len(teams))
+	if len(teams) != 5 {
+		return
+	}
+	assert.Equal(t, "東海大", teams[0].Team.Name)
+	assert.Equal(t, 1, teams[0].Rank)
+	assert.Equal(t, hakone.Seconds(10*60*60+620+540), teams[0].Total)
+	assert.True(t, teams[0].Qualified)
+	assert.Equal(t, "東洋大", teams[1].Team.Name)
+	assert.Equal(t, 2, teams[1].Rank)
+	assert.Equal(t, hakone.Seconds(10*60*60+610+675), teams[1].Total)
+	assert.True(t, teams[1].Qualified)
+	assert.Equal(t, "日本体育大", teams[2].Team.Name)
+	assert.Equal(t, 3, teams[2].Rank)
+	assert.False(t, teams[2].Qualified)
+	assert.False(t, teams[3].Scored)
+	assert.Equal(t, 0, teams[3].Rank)
+	assert.False(t, teams[4].Scored)
+
+	cutoff, ok := standings.CutoffLine()
+	assert.True(t, ok)
+	assert.Equal(t, teams[1].Total, cutoff)
+	assert.Equal(t, 2, len(standings.QualifiedTeams()))
+}
+
+func TestStandingsService_CalculateStandingsByNames_TieBreak(t *testing.T) {
+	records := make([]hakone.Record, 0)
+	for i := 0; i < 10; i++ {
+		// 早稲田大: order 2,4,...,20 / 東海大: order 1,5,7,...,21 with same total time
+		tokaiOrder := 3 + i*2
+		if i == 0 {
+			tokaiOrder = 1
+		}
+		records = append(records, newRecord(2+i*2, fmt.Sprintf("早稲田大ランナー-%d", i), "早稲田大", 1, 1, 100))
+		records = append(records, newRecord(tokaiOrder, fmt.Sprintf("東海大ランナー-%d", i), "東海大", 1, 1, 100))
+	}
+	service := StandingsService{
+		TeamRepository:  listTeamsTestRepository,
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
+		Edition:         hakone.Edition{QualifyingSlots: 1},
+	}
+
+	standings, err := service.CalculateStandingsByNames([]hakone.TeamName{"早稲田大", "東海大"})
+	assert.Nil(t, err)
+
+	teams := standings.Teams
+	assert.Equal(t, 2, len(teams))
+	if len(teams) != 2 {
+		return
+	}
+	assert.Equal(t, teams[0].Total, teams[1].Total)
+	assert.Equal(t, "東海大", teams[0].Team.Name)
+	assert.True(t, teams[0].Qualified)
+	assert.Equal(t, "早稲田大", teams[1].Team.Name)
+	assert.False(t, teams[1].Qualified)
+}
+
+func TestStandingsService_CalculateStandingsByNames_LessThan10Finishers(t *testing.T) {
+	records := makeRecords()
+	for i := 0; i < 5; i++ {
+		records = append(records, newRecord(200+i, fmt.Sprintf("日大ランナー-%d", i), "日本大", 1, 1, 10))
+	}
+	service := StandingsService{
+		TeamRepository:  listTeamsTestRepository,
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
+	}
+
+	standings, err := service.CalculateStandingsByNames([]hakone.TeamName{"日本大", "東洋大"})
+	assert.Nil(t, err)
+
+	teams := standings.Teams
+	assert.Equal(t, 2, len(teams))
+	if len(teams) != 2 {
+		return
+	}
+	assert.Equal(t, hakone.DefaultQualifyingSlots, standings.Edition.QualifyingSlots)
+	assert.Equal(t, "東洋大", teams[0].Team.Name)
+	assert.True(t, teams[0].Qualified)
+	assert.Equal(t, "日本大", teams[1].Team.Name)
+	assert.False(t, teams[1].Scored)
+	assert.False(t, teams[1].Qualified)
+	assert.Equal(t, 5, len(teams[1].Records))
+}
+
+func TestStandingsService_CalculateStandings_WithBonus(t *testing.T) {
+	records := makeRecords()
+	service := StandingsService{
+		TeamRepository:  listTeamsTestRepository,
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: records},
+		Edition:         hakone.Edition{QualifyingSlots: 2},
+		Bonuses: hakone.NewBonusTable([]hakone.TeamBonus{
+			{TeamId: 5, Deduction: hakone.Seconds(5 * 60)},
+		}),
+	}
+
+	standings, err := service.CalculateStandingsByNames([]hakone.TeamName{"東洋大", "東海大", "日本体育大"})
+	assert.Nil(t, err)
+
+	teams := standings.Teams
+	assert.Equal(t, 3, len(teams))
+	if len(teams) != 3 {
+		return
+	}
+	assert.Equal(t, "日本体育大", teams[0].Team.Name)
+	assert.Equal(t, hakone.Seconds(10*60*60+630+810), teams[0].RawTotal)
+	assert.Equal(t, hakone.Seconds(5*60), teams[0].Deduction)
+	assert.Equal(t, hakone.Seconds(10*60*60+630+810-5*60), teams[0].Total)
+	assert.True(t, teams[0].Qualified)
+	assert.Equal(t, "東海大", teams[1].Team.Name)
+	assert.Equal(t, teams[1].RawTotal, teams[1].Total)
+	assert.True(t, teams[1].Qualified)
+	assert.Equal(t, "東洋大", teams[2].Team.Name)
+	assert.False(t, teams[2].Qualified)
+
+	cutoff, ok := standings.CutoffLine()
+	assert.True(t, ok)
+	assert.Equal(t, teams[1].Total, cutoff)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+)
+
+// RegistryTeamRepository is a TeamRepository which resolves any name or alias of a team to the same team.
+type RegistryTeamRepository struct {
+	Registry *hakone.TeamRegistry
+}
+
+func (r *RegistryTeamRepository) ListAllTeams() []hakone.Team {
+	return r.Registry.Teams()
+}
+
+func (r *RegistryTeamRepository) FindTeamByName(name string) (*hakone.Team, error) {
+	team, ok := r.Registry.Resolve(name)
+	if !ok {
+		return nil, errors.Errorf("team not found with name \"%s\"", name)
+	}
+	return &team, nil
+}
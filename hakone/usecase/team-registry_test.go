@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRegistryTeamRepository_FindTeamByName(t *testing.T) {
+	repository := &RegistryTeamRepository{Registry: hakone.DefaultTeamRegistry()}
+
+	for _, name := range []string{"東洋大学", "東洋大", "Toyo Univ"} {
+		team, err := repository.FindTeamByName(name)
+		assert.Nil(t, err, name)
+		if team != nil {
+			assert.Equal(t, 2, team.Id, name)
+		}
+	}
+
+	_, err := repository.FindTeamByName("箱根大学")
+	assert.NotNil(t, err)
+}
+
+func TestRecordService_FindTop10RecordsByNames_Aliases(t *testing.T) {
+	service := RecordService{
+		TeamRepository:  &RegistryTeamRepository{Registry: hakone.DefaultTeamRegistry()},
+		Top10Repository: &Top10RecordsRepoTestImpl{Records: makeRecords()},
+	}
+
+	result, err := service.FindTop10RecordsByNames([]hakone.TeamName{"東洋大学", "Nippon Sport Science Univ"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(result.Records))
+	if len(result.Records) != 2 {
+		return
+	}
+	assert.Equal(t, "東洋大学", result.Records[0].Team.Name)
+	assert.Equal(t, 10, len(result.Records[0].Records))
+	assert.Equal(t, 1, result.Records[0].Records[0].RankAmongAll)
+	assert.Equal(t, "日本体育大学", result.Records[1].Team.Name)
+	assert.Equal(t, 10, len(result.Records[1].Records))
+}
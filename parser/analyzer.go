@@ -1,4 +1,4 @@
-package main
+package parser
 
 import (
 	"github.com/ledongthuc/pdf"
@@ -26,13 +26,29 @@ type Analyzer interface {
 	Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position)
 }
 
-func NewAnalyzer() Analyzer {
-	var def DefaultAnalyzer
+func NewAnalyzer(layout Layout) Analyzer {
+	def := DefaultAnalyzer{layout: &layout}
 	analyzer := DiscardingHeaderAnalyzer(def)
 	return &analyzer
 }
 
 type DefaultAnalyzer struct {
+	layout *Layout
+}
+
+func (a *DefaultAnalyzer) profile() Layout {
+	if a.layout == nil {
+		return DefaultLayout
+	}
+	return *a.layout
+}
+
+func (a *DefaultAnalyzer) isHeaderSeparator(s Str) bool {
+	return s.value == a.profile().HeaderSeparator
+}
+
+func (a *DefaultAnalyzer) isNotHeaderSeparator(s Str) bool {
+	return !a.isHeaderSeparator(s)
 }
 
 func (a *DefaultAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
@@ -120,10 +136,10 @@ func (d *DiscardingHeaderAnalyzer) Take(pos Position, texts []pdf.Text) (Str, An
 	if pos.isOutOfRangeOf(texts) {
 		return emptyStr, d, Position(max)
 	}
-	p = delegate.Seek(p, texts, Str.isNotHyphen)
+	p = delegate.Seek(p, texts, delegate.isNotHeaderSeparator)
 	p = d.SeekToHeaderFinish(p, texts)
 
-	analyzer := RunnerNameAnalyzer(delegate)
+	analyzer := PlaceAnalyzer(delegate)
 	return emptyStr, &analyzer, p
 }
 
@@ -135,9 +151,9 @@ func (d *DiscardingHeaderAnalyzer) SeekToHeaderFinish(start Position, texts []pd
 	}
 	for startHyphen := false; startHyphen == false; {
 		current, _, p := analyzer.Take(pos, texts)
-		if current.isHyphen() {
+		if analyzer.isHeaderSeparator(current) {
 			next, _, _ := analyzer.Take(p, texts)
-			if next.isHyphen() {
+			if analyzer.isHeaderSeparator(next) {
 				startHyphen = true
 			}
 		}
@@ -148,7 +164,7 @@ func (d *DiscardingHeaderAnalyzer) SeekToHeaderFinish(start Position, texts []pd
 	}
 	for endHyphen := false; endHyphen == false; {
 		current, _, p := analyzer.Take(pos, texts)
-		if current.isNotHyphen() {
+		if analyzer.isNotHeaderSeparator(current) {
 			endHyphen = true
 		} else {
 			pos = p
@@ -160,12 +176,54 @@ func (d *DiscardingHeaderAnalyzer) SeekToHeaderFinish(start Position, texts []pd
 	return pos
 }
 
-func (s Str) isHyphen() bool {
-	return s.value == "-"
+type PlaceAnalyzer DefaultAnalyzer
+
+// Take collects leading digits as the place, digits apart more than NumberGap are left for BibAnalyzer.
+func (pa *PlaceAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
+	delegate := DefaultAnalyzer(*pa)
+	analyzer := BibAnalyzer(delegate)
+	p, strs := delegate.takeNumberGroup(pos, texts)
+	if len(strs) == 0 {
+		return emptyStr, &analyzer, p
+	}
+	cs := combineStr(strs)
+	cs.strType = PlaceValue
+	return cs, &analyzer, p
 }
 
-func (s Str) isNotHyphen() bool {
-	return !s.isHyphen()
+type BibAnalyzer DefaultAnalyzer
+
+func (ba *BibAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
+	delegate := DefaultAnalyzer(*ba)
+	analyzer := RunnerNameAnalyzer(delegate)
+	p, strs := delegate.takeNumberGroup(pos, texts)
+	if len(strs) == 0 {
+		return emptyStr, &analyzer, p
+	}
+	cs := combineStr(strs)
+	cs.strType = BibValue
+	return cs, &analyzer, p
+}
+
+func (a *DefaultAnalyzer) takeNumberGroup(pos Position, texts []pdf.Text) (Position, []Str) {
+	gap := a.profile().NumberGap
+	strs := make([]Str, 0)
+	p := pos
+	for !p.isOutOfRangeOf(texts) {
+		current, _, next := a.Take(p, texts)
+		if current.isNotNumber() {
+			break
+		}
+		if len(strs) > 0 {
+			last := strs[len(strs)-1]
+			if current.yAxis != last.yAxis || current.xAxis-last.xAxis > gap {
+				break
+			}
+		}
+		strs = append(strs, current)
+		p = next
+	}
+	return p, strs
 }
 
 type RunnerNameAnalyzer DefaultAnalyzer
@@ -220,9 +278,19 @@ func (s Str) endsWith(prefix string) bool {
 
 type TeamAnalyzer DefaultAnalyzer
 
+// Take collects the team name until a number, a new line or a gap more than NumberGap, which is the note of runners
+// without any time like "DNS".
 func (ta *TeamAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
 	delegate := DefaultAnalyzer(*ta)
-	p, strs := delegate.SeekAndCollect(pos, texts, Str.isNotNumber)
+	gap := delegate.profile().NumberGap
+	last := emptyStr
+	p, strs := delegate.SeekAndCollect(pos, texts, func(s Str) bool {
+		if s.isNumber() || !last.empty && (s.yAxis != last.yAxis || s.xAxis-last.xAxis > gap) {
+			return false
+		}
+		last = s
+		return true
+	})
 	name := combineStr(strs)
 	name.strType = TeamNameValue
 
@@ -331,9 +399,24 @@ func (t *TimeAnalyzer) takeTime(pos Position, size int, texts []pdf.Text, colonP
 		strs[i] = current
 		p = next
 	}
+	strs, p = t.takeFraction(p, texts, strs)
 	return combineStr(strs), p
 }
 
+// takeFraction appends fraction digits like ".4" following the time if exists.
+func (t *TimeAnalyzer) takeFraction(pos Position, texts []pdf.Text, strs []Str) ([]Str, Position) {
+	point, _, next := t.delegate.Take(pos, texts)
+	if point.empty || point.value != "." || point.yAxis != t.expectedYAxis {
+		return strs, pos
+	}
+	p, digits := t.delegate.takeNumberGroup(next, texts)
+	if len(digits) == 0 || digits[0].yAxis != t.expectedYAxis || digits[0].xAxis-point.xAxis > t.delegate.profile().NumberGap {
+		return strs, pos
+	}
+	result := append(strs, point)
+	return append(result, digits...), p
+}
+
 func delegationAnalyzeTime(
 	strType StrType,
 	ta *TimeAnalyzer,
@@ -397,7 +480,7 @@ func (t *FinishTimeAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer
 	return delegationAnalyzeTime(ResultTime, &analyzer, pos, texts, func(np Position) Analyzer {
 		def := analyzer.delegate
 		ns, _, _ := def.Take(np, texts)
-		next := DiscardingRomeAndNativeAnalyzer{def, ns.yAxis}
+		next := RomanizedNameAnalyzer{def, ns.yAxis}
 		return &next
 	})
 }
@@ -413,42 +496,65 @@ func (n *NoteAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Posi
 		return emptyStr, n, Position(max)
 	}
 	p, strs := n.delegate.SeekAndCollect(pos, texts, func(s Str) bool {
-		return s.isNoteChar(n.expectedYAxis)
+		return s.isNoteChar(n.delegate.profile().NoteChars, n.expectedYAxis)
 	})
 	cs := combineStr(strs)
 	cs.strType = Notes
 
 	nextStr, _, _ := n.delegate.Take(p, texts)
 
-	da := DiscardingRomeAndNativeAnalyzer{n.delegate, nextStr.yAxis}
+	da := RomanizedNameAnalyzer{n.delegate, nextStr.yAxis}
 
 	return cs, &da, p
 }
 
-var noteChars = regexp.MustCompile("^[A-Z0-9]$")
-
-func (s Str) isNoteChar(sameLineYAxis float64) bool {
+func (s Str) isNoteChar(noteChars *regexp.Regexp, sameLineYAxis float64) bool {
 	return noteChars.MatchString(s.value) && s.yAxis == sameLineYAxis
 }
 
-func (s Str) isNotNoteChar(sameLineYAxis float64) bool {
-	return s.isNoteChar(sameLineYAxis)
+type RomanizedNameAnalyzer struct {
+	delegate      DefaultAnalyzer
+	expectedYAxis float64
 }
 
-type DiscardingRomeAndNativeAnalyzer struct {
+func (r *RomanizedNameAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
+	p, strs := r.delegate.SeekAndCollect(pos, texts, func(s Str) bool {
+		return s.isRomanChar() && s.yAxis == r.expectedYAxis
+	})
+	next := NationalityAnalyzer{r.delegate, r.expectedYAxis}
+	if len(strs) == 0 {
+		return emptyStr, &next, p
+	}
+	cs := combineStr(strs)
+	cs.value = strings.TrimSpace(cs.value)
+	cs.strType = RomanizedNameValue
+	return cs, &next, p
+}
+
+var romanChars = regexp.MustCompile("^[A-Za-z.'\\- ]$")
+
+func (s Str) isRomanChar() bool {
+	return romanChars.MatchString(s.value)
+}
+
+type NationalityAnalyzer struct {
 	delegate      DefaultAnalyzer
 	expectedYAxis float64
 }
 
-func (d *DiscardingRomeAndNativeAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
-	delegate := d.delegate
-	p := pos
-	p = delegate.Seek(p, texts, func(s Str) bool {
-		return s.isNotParenthesis() && s.yAxis == d.expectedYAxis
+func (n *NationalityAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer, Position) {
+	p, strs := n.delegate.SeekAndCollect(pos, texts, func(s Str) bool {
+		return s.isNotParenthesis() && s.yAxis == n.expectedYAxis
 	})
-	analyzer := TimeAnalyzer{delegate: delegate, expectedYAxis: d.expectedYAxis}
+	analyzer := TimeAnalyzer{delegate: n.delegate, expectedYAxis: n.expectedYAxis}
 	next := RapTo10kmAnalyzer(analyzer)
-	return emptyStr, &next, p
+	if len(strs) == 0 {
+		return emptyStr, &next, p
+	}
+	cs := combineStr(strs)
+	cs.value = strings.TrimSpace(cs.value)
+	cs.strType = NationalityValue
+	return cs, &next, p
 }
 
 type RapTo10kmAnalyzer TimeAnalyzer
@@ -461,7 +567,7 @@ func (ra *RapTo10kmAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer
 		return emptyStr, analyzeDone, Position(len(texts))
 	} else if time.empty {
 		def := analyzer.delegate
-		na := RunnerNameAnalyzer(def)
+		na := PlaceAnalyzer(def)
 		return emptyStr, &na, pos
 	}
 	time.strType = Rap5kmTo10km
@@ -484,7 +590,7 @@ func (ra *RapTo15kmAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer
 		return emptyStr, analyzeDone, Position(len(texts))
 	} else if time.empty {
 		def := analyzer.delegate
-		na := RunnerNameAnalyzer(def)
+		na := PlaceAnalyzer(def)
 		return emptyStr, &na, pos
 	}
 	time.strType = Rap10kmTo15km
@@ -506,7 +612,7 @@ func (ra *RapTo20kmAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer
 		return emptyStr, analyzeDone, Position(len(texts))
 	} else if time.empty {
 		def := analyzer.delegate
-		na := RunnerNameAnalyzer(def)
+		na := PlaceAnalyzer(def)
 		return emptyStr, &na, pos
 	}
 
@@ -517,7 +623,7 @@ func (ra *RapTo20kmAnalyzer) Take(pos Position, texts []pdf.Text) (Str, Analyzer
 	}
 
 	def := analyzer.delegate
-	na := RunnerNameAnalyzer(def)
+	na := PlaceAnalyzer(def)
 
 	return time, &na, next
 }
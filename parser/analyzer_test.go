@@ -1,4 +1,4 @@
-package main
+package parser
 
 import (
 	"fmt"
@@ -577,7 +577,8 @@ func TestTimeAnalyzers(t *testing.T) {
 	timeOf15km, analyzer, pos := analyzer.Take(pos, texts)
 	timeOf20km, analyzer, pos := analyzer.Take(pos, texts)
 	timeOfHalf, analyzer, pos := analyzer.Take(pos, texts)
-	mayBeEmpty, analyzer, pos := analyzer.Take(pos, texts)
+	romanizedName, analyzer, pos := analyzer.Take(pos, texts)
+	nationality, analyzer, pos := analyzer.Take(pos, texts)
 	rapFrom5kmTo10km, analyzer, pos := analyzer.Take(pos, texts)
 	rapFrom10kmTo15km, analyzer, pos := analyzer.Take(pos, texts)
 	rapFrom15kmTo20km, analyzer, pos := analyzer.Take(pos, texts)
@@ -590,7 +591,8 @@ func TestTimeAnalyzers(t *testing.T) {
 	assert.Equal(t, makeTime(21.1, 21.0, "52:12", Time15km), timeOf15km, "15kmタイム")
 	assert.Equal(t, makeTime(30.8, 21.0, "1:11:34", Time20km), timeOf20km, "20kmタイム")
 	assert.Equal(t, makeTime(40.5, 21.0, "1:15:23", ResultTime), timeOfHalf, "ハーフマラソンタイム")
-	assert.Equal(t, emptyStr, mayBeEmpty)
+	assert.Equal(t, makeTime(1.7, 24.2, "ISHIDA", RomanizedNameValue), romanizedName, "ローマ字氏名")
+	assert.Equal(t, makeTime(11.4, 24.2, "岐阜", NationalityValue), nationality, "国籍・出身")
 	assert.Equal(t, makeTime(22.4, 24.2, "17:30", Rap5kmTo10km), rapFrom5kmTo10km, "ラップ5-10")
 	assert.Equal(t, makeTime(32.1, 24.2, "17:27", Rap10kmTo15km), rapFrom10kmTo15km, "ラップ10-15")
 	assert.Equal(t, makeTime(41.8, 24.2, "19:22", Rap15kmTo20km), rapFrom15kmTo20km, "ラップ15-20")
@@ -686,18 +688,22 @@ func TestTimeAnalyzers_FinishThenNextLine(t *testing.T) {
 	time15km, analyzer, pos := analyzer.Take(pos, texts)
 	mayBeEmpty, analyzer, pos := analyzer.Take(pos, texts)
 	mayNote, analyzer, pos := analyzer.Take(pos, texts)
-	mayBeEmpty2, analyzer, pos := analyzer.Take(pos, texts)
+	romanizedName, analyzer, pos := analyzer.Take(pos, texts)
+	nationality, analyzer, pos := analyzer.Take(pos, texts)
 	rap5kmTo10km, analyzer, pos := analyzer.Take(pos, texts)
 	mayBeEmpty3, analyzer, pos := analyzer.Take(pos, texts) // 解析失敗 -> 次の行
 
-	_, ok := analyzer.(*RunnerNameAnalyzer)
+	_, ok := analyzer.(*PlaceAnalyzer)
 	assert.True(t, ok, "次の行", analyzer)
 	assert.Equal(t, Position(len(texts)-4), pos, "解析終了ポジション")
 
 	assert.Equal(t, Time5km, time5km.strType, "5kmタイム", time5km)
 	assert.Equal(t, Time10km, time10km.strType, "10kmタイム", time10km)
 	assert.Equal(t, Time15km, time15km.strType, "15kmタイム", time15km)
-	assert.Equal(t, emptyStr, mayBeEmpty2, "20km取得失敗")
+	assert.Equal(t, RomanizedNameValue, romanizedName.strType, "ローマ字氏名タイプ")
+	assert.Equal(t, "ISHIDA", romanizedName.value, "ローマ字氏名")
+	assert.Equal(t, NationalityValue, nationality.strType, "国籍・出身タイプ")
+	assert.Equal(t, "岐阜", nationality.value, "国籍・出身")
 	assert.Equal(t, Notes, mayNote.strType, "ノートタイプ")
 	assert.Equal(t, "DQDQ2", mayNote.value, "ノートDQDQ2")
 	assert.Equal(t, emptyStr, mayBeEmpty, "読み飛ばし")
@@ -791,7 +797,8 @@ func TestTimeAnalyzers_Finish(t *testing.T) {
 	time15km, analyzer, pos := analyzer.Take(pos, texts)
 	mayBeEmpty, analyzer, pos := analyzer.Take(pos, texts)
 	mayNote, analyzer, pos := analyzer.Take(pos, texts)
-	mayBeEmpty2, analyzer, pos := analyzer.Take(pos, texts)
+	romanizedName, analyzer, pos := analyzer.Take(pos, texts)
+	nationality, analyzer, pos := analyzer.Take(pos, texts)
 	rap5kmTo10km, analyzer, pos := analyzer.Take(pos, texts)
 	mayBeEmpty3, analyzer, pos := analyzer.Take(pos, texts) // 終了
 
@@ -802,10 +809,132 @@ func TestTimeAnalyzers_Finish(t *testing.T) {
 	assert.Equal(t, Time5km, time5km.strType, "5kmタイム", time5km)
 	assert.Equal(t, Time10km, time10km.strType, "10kmタイム", time10km)
 	assert.Equal(t, Time15km, time15km.strType, "15kmタイム", time15km)
-	assert.Equal(t, emptyStr, mayBeEmpty2, "20km取得失敗")
+	assert.Equal(t, RomanizedNameValue, romanizedName.strType, "ローマ字氏名タイプ")
+	assert.Equal(t, "ISHIDA", romanizedName.value, "ローマ字氏名")
+	assert.Equal(t, NationalityValue, nationality.strType, "国籍・出身タイプ")
+	assert.Equal(t, "岐阜", nationality.value, "国籍・出身")
 	assert.Equal(t, Notes, mayNote.strType, "ノートタイプ")
 	assert.Equal(t, "DQDQ2", mayNote.value, "ノートDQDQ2")
 	assert.Equal(t, emptyStr, mayBeEmpty, "読み飛ばし")
 	assert.Equal(t, Rap5kmTo10km, rap5kmTo10km.strType, "ラップ5km-10km", rap5kmTo10km)
 	assert.Equal(t, emptyStr, mayBeEmpty3, "終了")
 }
+
+func TestPlaceAnalyzer_Take_WithBib(t *testing.T) {
+	texts := []pdf.Text{
+		makeText(1.0, 20.0, "1"), // 0
+		makeBytes(1.0, 20.0),
+		makeText(3.0, 20.0, "2"),
+		makeBytes(3.0, 20.0),
+		makeText(21.0, 20.0, "3"),
+		makeBytes(21.0, 20.0),
+		makeText(23.0, 20.0, "0"),
+		makeBytes(23.0, 20.0),
+		makeText(25.0, 20.0, "5"),
+		makeBytes(25.0, 20.0),
+		makeText(41.0, 20.0, "石"), // 10
+		makeBytes(41.0, 20.0),
+		makeText(43.0, 20.0, "田"),
+		makeBytes(43.0, 20.0),
+	}
+
+	var def DefaultAnalyzer
+	analyzer := PlaceAnalyzer(def)
+
+	place, next, pos := analyzer.Take(0, texts)
+	assert.Equal(t, Position(4), pos)
+	assert.Equal(t, PlaceValue, place.strType)
+	assert.Equal(t, "12", place.value)
+
+	bib, next, pos := next.Take(pos, texts)
+	assert.Equal(t, Position(10), pos)
+	assert.Equal(t, BibValue, bib.strType)
+	assert.Equal(t, "305", bib.value)
+	assert.Equal(t, "*RunnerNameAnalyzer", AnalyzerName(next))
+}
+
+func TestPlaceAnalyzer_Take_WithoutBib(t *testing.T) {
+	texts := []pdf.Text{
+		makeText(1.0, 20.0, "1"), // 0
+		makeBytes(1.0, 20.0),
+		makeText(3.0, 20.0, "2"),
+		makeBytes(3.0, 20.0),
+		makeText(5.0, 20.0, "石"),
+		makeBytes(5.0, 20.0),
+	}
+
+	var def DefaultAnalyzer
+	analyzer := PlaceAnalyzer(def)
+
+	place, next, pos := analyzer.Take(0, texts)
+	assert.Equal(t, "12", place.value)
+	bib, _, pos := next.Take(pos, texts)
+	assert.Equal(t, Position(4), pos)
+	assert.Equal(t, emptyStr, bib)
+}
+
+func TestRomanizedNameAnalyzer_Take(t *testing.T) {
+	texts := []pdf.Text{
+		makeText(1.0, 24.0, "M"), // 0
+		makeBytes(1.0, 24.0),
+		makeText(3.0, 24.0, "."),
+		makeBytes(3.0, 24.0),
+		makeText(5.0, 24.0, "K"),
+		makeBytes(5.0, 24.0),
+		makeText(7.0, 24.0, "I"),
+		makeBytes(7.0, 24.0),
+		makeText(9.0, 24.0, "P"),
+		makeBytes(9.0, 24.0),
+		makeText(15.0, 24.0, "ケ"), // 10
+		makeBytes(15.0, 24.0),
+		makeText(17.0, 24.0, "ニ"),
+		makeBytes(17.0, 24.0),
+		makeText(19.0, 24.0, "ア"),
+		makeBytes(19.0, 24.0),
+		makeText(25.0, 24.0, "("), // 16
+		makeBytes(25.0, 24.0),
+	}
+
+	var def DefaultAnalyzer
+	analyzer := RomanizedNameAnalyzer{delegate: def, expectedYAxis: 24.0}
+
+	name, next, pos := analyzer.Take(0, texts)
+	assert.Equal(t, Position(10), pos)
+	assert.Equal(t, RomanizedNameValue, name.strType)
+	assert.Equal(t, "M.KIP", name.value)
+
+	nationality, next, pos := next.Take(pos, texts)
+	assert.Equal(t, Position(16), pos)
+	assert.Equal(t, NationalityValue, nationality.strType)
+	assert.Equal(t, "ケニア", nationality.value)
+	assert.Equal(t, "*RapTo10kmAnalyzer", AnalyzerName(next))
+}
+
+func TestTimeAnalyzerTakeMinutesTime_WithFraction(t *testing.T) {
+	texts := []pdf.Text{
+		makeText(1.0, 2.0, "1"), // 0
+		makeBytes(1.0, 2.0),
+		makeText(3.0, 2.0, "4"),
+		makeBytes(3.0, 2.0),
+		makeText(5.0, 2.0, ":"),
+		makeBytes(5.0, 2.0),
+		makeText(7.0, 2.0, "4"),
+		makeBytes(7.0, 2.0),
+		makeText(9.0, 2.0, "7"),
+		makeBytes(9.0, 2.0),
+		makeText(11.0, 2.0, "."), // 10
+		makeBytes(11.0, 2.0),
+		makeText(13.0, 2.0, "3"),
+		makeBytes(13.0, 2.0),
+		makeText(21.0, 2.0, "2"), // 14
+		makeBytes(21.0, 2.0),
+	}
+
+	var def DefaultAnalyzer
+	analyzer := TimeAnalyzer{delegate: def, expectedYAxis: 2.0}
+
+	result, pos := analyzer.takeMinutesTime(0, texts)
+
+	assert.Equal(t, Position(14), pos)
+	assert.Equal(t, "14:47.3", result.value)
+}
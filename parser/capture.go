@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/ledongthuc/pdf"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+)
+
+// CapturedText is a line of the capture file, which keeps a page of pdf as fixture without the original pdf.
+type CapturedText struct {
+	Font     string  `json:"font,omitempty"`
+	FontSize float64 `json:"font_size,omitempty"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	W        float64 `json:"w,omitempty"`
+	S        string  `json:"s"`
+}
+
+// CaptureFileName is the name of the capture file of the page in the capture directory.
+func CaptureFileName(pageNum int) string {
+	return fmt.Sprintf("page-%03d.texts.jsonl", pageNum)
+}
+
+func WriteCaptureFile(path string, texts []pdf.Text) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open capture file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	encoder := json.NewEncoder(file)
+	for _, text := range texts {
+		captured := CapturedText{Font: text.Font, FontSize: text.FontSize, X: text.X, Y: text.Y, W: text.W, S: text.S}
+		if err := encoder.Encode(captured); err != nil {
+			return errors.Wrapf(err, "failed to write text %v", text)
+		}
+	}
+	return nil
+}
+
+func ReadCaptureFile(path string) ([]pdf.Text, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open capture file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	texts := make([]pdf.Text, 0)
+	scanner := bufio.NewScanner(file)
+	for i := 1; scanner.Scan(); i++ {
+		var captured CapturedText
+		if err := json.Unmarshal(scanner.Bytes(), &captured); err != nil {
+			return nil, errors.Wrapf(err, "invalid text at line %d of %s", i, path)
+		}
+		texts = append(texts, pdf.Text{
+			Font:     captured.Font,
+			FontSize: captured.FontSize,
+			X:        captured.X,
+			Y:        captured.Y,
+			W:        captured.W,
+			S:        captured.S,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read capture file %s", path)
+	}
+	return texts, nil
+}
+
+// ReadCaptureDir reads capture files of consecutive pages from the first page in the directory.
+func ReadCaptureDir(dir string) ([][]pdf.Text, error) {
+	pages := make([][]pdf.Text, 0)
+	for pageNum := 1; ; pageNum++ {
+		path := filepath.Join(dir, CaptureFileName(pageNum))
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		texts, err := ReadCaptureFile(path)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, texts)
+	}
+	if len(pages) == 0 {
+		return nil, errors.New(fmt.Sprintf("no capture file in %s", dir))
+	}
+	return pages, nil
+}
+
+func (p *Parser) capture(pageNum int, texts []pdf.Text) error {
+	if p.CaptureDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(p.CaptureDir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create capture directory %s", p.CaptureDir)
+	}
+	return WriteCaptureFile(filepath.Join(p.CaptureDir, CaptureFileName(pageNum)), texts)
+}
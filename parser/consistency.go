@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+)
+
+// Source is where a record is read from. Position is the index of the first text of the record in the glyph chain
+// layout. Row is the number of the record in the page from 1 in the column table layout, and zero in the glyph chain
+// layout.
+type Source struct {
+	Page     int
+	Position Position
+	Row      int
+}
+
+// Inconsistency is a problem of the times of a record, which is caused by pdf extraction errors mostly.
+type Inconsistency struct {
+	Source  Source
+	Record  hakone.Record
+	Problem string
+}
+
+func (i Inconsistency) String() string {
+	where := fmt.Sprintf("position: %d", i.Source.Position)
+	if i.Source.Row > 0 {
+		where = fmt.Sprintf("row: %d", i.Source.Row)
+	}
+	return fmt.Sprintf("inconsistent record: %s(%s) page: %d, %s, %s",
+		i.Record.Runner, i.Record.Team, i.Source.Page, where, i.Problem)
+}
+
+type checkpoint struct {
+	name string
+	time hakone.Time
+}
+
+type rap struct {
+	name string
+	from checkpoint
+	to   checkpoint
+	time hakone.Time
+}
+
+// agrees compares the rap with the difference of pass times at the precision printed on the sheet. A rap in whole
+// seconds agrees with a difference less than a second apart, because the pass times may have fractions.
+func (r rap) agrees(diff hakone.Time) bool {
+	if r.time.HasFraction() {
+		return diff == r.time
+	}
+	gap := diff.Sub(r.time)
+	return -hakone.Second < gap && gap < hakone.Second
+}
+
+// CheckSplits returns problems of the record, pass times must increase, raps must be differences of pass times and
+// the finish time must be after 20km. Missing times are not checked.
+func CheckSplits(record hakone.Record) []string {
+	problems := make([]string, 0)
+	checkpoints := []checkpoint{
+		{name: "5km", time: record.TimeOf5km},
+		{name: "10km", time: record.TimeOf10km},
+		{name: "15km", time: record.TimeOf15km},
+		{name: "20km", time: record.TimeOf20km},
+	}
+	var previous *checkpoint
+	for i := range checkpoints {
+		current := checkpoints[i]
+		if current.time == 0 {
+			continue
+		}
+		if previous != nil && !previous.time.Before(current.time) {
+			problems = append(problems, fmt.Sprintf("%s(%s) is not after %s(%s)",
+				current.name, current.time, previous.name, previous.time))
+		}
+		previous = &checkpoints[i]
+	}
+	if record.FinishTime != 0 && previous != nil && !previous.time.Before(record.FinishTime) {
+		problems = append(problems, fmt.Sprintf("finish(%s) is not after %s(%s)",
+			record.FinishTime, previous.name, previous.time))
+	}
+
+	raps := []rap{
+		{name: "rap 5-10km", from: checkpoints[0], to: checkpoints[1], time: record.RapFrom5kmTo10km},
+		{name: "rap 10-15km", from: checkpoints[1], to: checkpoints[2], time: record.RapFrom10kmTo15km},
+		{name: "rap 15-20km", from: checkpoints[2], to: checkpoints[3], time: record.RapFrom15kmTo20km},
+	}
+	for _, r := range raps {
+		if r.time == 0 || r.from.time == 0 || r.to.time == 0 {
+			continue
+		}
+		if diff := r.to.time.Sub(r.from.time); !r.agrees(diff) {
+			problems = append(problems, fmt.Sprintf("%s(%s) differs from %s - %s(%s)",
+				r.name, r.time, r.to.name, r.from.name, diff))
+		}
+	}
+	return problems
+}
+
+func inconsistenciesOf(source Source, record hakone.Record) []Inconsistency {
+	problems := CheckSplits(record)
+	inconsistencies := make([]Inconsistency, len(problems))
+	for i, problem := range problems {
+		inconsistencies[i] = Inconsistency{Source: source, Record: record, Problem: problem}
+	}
+	return inconsistencies
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func consistentRecord() hakone.Record {
+	return hakone.Record{
+		Runner:            "a",
+		TimeOf5km:         hakone.Seconds(14*60 + 47),
+		TimeOf10km:        hakone.Seconds(29*60 + 29),
+		TimeOf15km:        hakone.Seconds(44*60 + 20),
+		TimeOf20km:        hakone.Seconds(59*60 + 9),
+		FinishTime:        hakone.Seconds(62*60 + 23),
+		RapFrom5kmTo10km:  hakone.Seconds(14*60 + 42),
+		RapFrom10kmTo15km: hakone.Seconds(14*60 + 51),
+		RapFrom15kmTo20km: hakone.Seconds(14*60 + 49),
+	}
+}
+
+func TestCheckSplits_Consistent(t *testing.T) {
+	assert.Empty(t, CheckSplits(consistentRecord()))
+}
+
+func TestCheckSplits_NotMonotonic(t *testing.T) {
+	record := consistentRecord()
+	record.TimeOf15km = hakone.Seconds(24*60 + 20)
+	record.RapFrom10kmTo15km = 0
+	record.RapFrom15kmTo20km = 0
+
+	problems := CheckSplits(record)
+
+	assert.Equal(t, []string{"15km(24:20) is not after 10km(29:29)"}, problems)
+}
+
+func TestCheckSplits_RapDiffers(t *testing.T) {
+	record := consistentRecord()
+	record.RapFrom10kmTo15km = hakone.Seconds(14*60 + 15)
+
+	problems := CheckSplits(record)
+
+	assert.Equal(t, []string{"rap 10-15km(14:15) differs from 15km - 10km(14:51)"}, problems)
+}
+
+func TestCheckSplits_RapInWholeSeconds(t *testing.T) {
+	record := consistentRecord()
+	record.TimeOf5km += 300 * hakone.Millisecond
+	record.TimeOf10km += 100 * hakone.Millisecond
+	assert.Empty(t, CheckSplits(record))
+
+	record.RapFrom5kmTo10km = hakone.Seconds(14*60+41) + 200*hakone.Millisecond
+	assert.Equal(t, []string{"rap 5-10km(14:41.2) differs from 10km - 5km(14:41.8)"}, CheckSplits(record))
+
+	record.RapFrom5kmTo10km = hakone.Seconds(14*60 + 40)
+	assert.Equal(t, []string{"rap 5-10km(14:40) differs from 10km - 5km(14:41.8)"}, CheckSplits(record))
+}
+
+func TestCheckSplits_FinishBefore20km(t *testing.T) {
+	record := consistentRecord()
+	record.FinishTime = hakone.Seconds(52*60 + 23)
+
+	problems := CheckSplits(record)
+
+	assert.Equal(t, []string{"finish(52:23) is not after 20km(59:09)"}, problems)
+}
+
+func TestCheckSplits_MissingTimes(t *testing.T) {
+	record := hakone.Record{
+		TimeOf5km:  hakone.Seconds(14*60 + 47),
+		TimeOf10km: hakone.Seconds(29*60 + 29),
+		Status:     hakone.StatusDNF,
+	}
+	assert.Empty(t, CheckSplits(record))
+}
+
+func TestParser_ParsePage_Inconsistencies(t *testing.T) {
+	page := tablePage()
+	for i, text := range page {
+		// extraction error turns the rap (14:51) into (14:11)
+		if text.X == 67.0 && text.Y == 68.0 && text.S == "5" {
+			page[i].S = "1"
+		}
+	}
+	p := Parser{Layout: ColumnLayout}
+
+	_, err := p.ParsePage(3, page)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(p.Inconsistencies))
+	if len(p.Inconsistencies) != 1 {
+		return
+	}
+	assert.Equal(t, Source{Page: 3, Row: 1}, p.Inconsistencies[0].Source)
+	assert.Equal(t, "inconsistent record: J.MWANGI2(東京国際大) page: 3, row: 1, rap 10-15km(14:11) differs from 15km - 10km(14:51)",
+		p.Inconsistencies[0].String())
+}
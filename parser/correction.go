@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Correction patches fields of a record identified by the bib, or by the official place when the bib is zero.
+// Fields are keyed by the json names of hakone.Record and have values in the json format.
+type Correction struct {
+	Bib    int                        `json:"bib,omitempty"`
+	Place  int                        `json:"place,omitempty"`
+	Fields map[string]json.RawMessage `json:"fields"`
+	Reason string                     `json:"reason,omitempty"`
+}
+
+func (c Correction) key() string {
+	if c.Bib > 0 {
+		return fmt.Sprintf("bib: %d", c.Bib)
+	}
+	return fmt.Sprintf("place: %d", c.Place)
+}
+
+func (c Correction) matches(record hakone.Record) bool {
+	if c.Bib > 0 {
+		return record.Bib == c.Bib
+	}
+	return c.Place > 0 && record.Place == c.Place
+}
+
+// AppliedPatch is an audit log entry of a field changed by a correction.
+type AppliedPatch struct {
+	Bib    int             `json:"bib"`
+	Place  int             `json:"place"`
+	Runner hakone.Runner   `json:"runner"`
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	Reason string          `json:"reason,omitempty"`
+}
+
+func (ap AppliedPatch) String() string {
+	return fmt.Sprintf("corrected: %s(bib: %d, place: %d) %s: %s -> %s",
+		ap.Runner, ap.Bib, ap.Place, ap.Field, ap.Before, ap.After)
+}
+
+// LoadCorrections reads a jsonl file of corrections, empty lines are ignored.
+func LoadCorrections(path string) ([]Correction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open corrections file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	corrections := make([]Correction, 0)
+	scanner := bufio.NewScanner(file)
+	for i := 1; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var correction Correction
+		if err := json.Unmarshal([]byte(line), &correction); err != nil {
+			return nil, errors.Wrapf(err, "invalid correction at line %d of %s", i, path)
+		}
+		if correction.Bib <= 0 && correction.Place <= 0 {
+			return nil, errors.New(fmt.Sprintf("correction without bib nor place at line %d of %s", i, path))
+		}
+		corrections = append(corrections, correction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read corrections file %s", path)
+	}
+	return corrections, nil
+}
+
+// ApplyCorrections patches records in place, a correction must match exactly one record. Status is derived from the
+// note again when the note is corrected without the status.
+func ApplyCorrections(records []hakone.Record, corrections []Correction) ([]AppliedPatch, error) {
+	patches := make([]AppliedPatch, 0)
+	for _, correction := range corrections {
+		index := -1
+		for i, record := range records {
+			if !correction.matches(record) {
+				continue
+			}
+			if index >= 0 {
+				return patches, errors.New(fmt.Sprintf("correction of %s matches more than one record", correction.key()))
+			}
+			index = i
+		}
+		if index < 0 {
+			return patches, errors.New(fmt.Sprintf("correction of %s matches no record", correction.key()))
+		}
+		patched, applied, err := correction.apply(records[index])
+		if err != nil {
+			return patches, errors.Wrapf(err, "failed to apply correction of %s", correction.key())
+		}
+		records[index] = patched
+		patches = append(patches, applied...)
+	}
+	return patches, nil
+}
+
+func (c Correction) apply(record hakone.Record) (hakone.Record, []AppliedPatch, error) {
+	names := make([]string, 0, len(c.Fields))
+	for name := range c.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indexes := recordFieldIndexes()
+	value := reflect.ValueOf(&record).Elem()
+	patches := make([]AppliedPatch, 0, len(names))
+	for _, name := range names {
+		index, ok := indexes[name]
+		if !ok {
+			return record, nil, errors.New(fmt.Sprintf("unknown field %s", name))
+		}
+		field := value.Field(index)
+		before, err := marshalField(field.Interface())
+		if err != nil {
+			return record, nil, errors.Wrapf(err, "failed to read field %s", name)
+		}
+		if err := json.Unmarshal(c.Fields[name], field.Addr().Interface()); err != nil {
+			return record, nil, errors.Wrapf(err, "invalid value of field %s", name)
+		}
+		after, err := marshalField(field.Interface())
+		if err != nil {
+			return record, nil, errors.Wrapf(err, "failed to read field %s", name)
+		}
+		patches = append(patches, AppliedPatch{Field: name, Before: before, After: after, Reason: c.Reason})
+	}
+	_, hasNote := c.Fields["Note"]
+	_, hasStatus := c.Fields["status"]
+	if hasNote && !hasStatus {
+		status, err := statusOf(record)
+		if err != nil {
+			return record, nil, err
+		}
+		record.Status = status
+	}
+	for i := range patches {
+		patches[i].Bib = record.Bib
+		patches[i].Place = record.Place
+		patches[i].Runner = record.Runner
+	}
+	return record, patches, nil
+}
+
+// marshalField keeps fractions of times in the audit log, which the json of hakone.Time truncates.
+func marshalField(value interface{}) ([]byte, error) {
+	if time, ok := value.(hakone.Time); ok {
+		return []byte(time.DecimalSeconds()), nil
+	}
+	return json.Marshal(value)
+}
+
+// recordFieldIndexes maps json names of hakone.Record to indexes of the fields.
+func recordFieldIndexes() map[string]int {
+	t := reflect.TypeOf(hakone.Record{})
+	indexes := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		indexes[name] = i
+	}
+	return indexes
+}
+
+func WriteAuditFile(path string, patches []AppliedPatch) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open audit file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	encoder := json.NewEncoder(file)
+	for _, patch := range patches {
+		if err := encoder.Encode(patch); err != nil {
+			return errors.Wrapf(err, "failed to write patch %v", patch)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"encoding/json"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func correctionRecords() []hakone.Record {
+	return []hakone.Record{
+		{Place: 1, Bib: 42, Runner: "a", FinishTime: hakone.Seconds(3743)},
+		{Place: 2, Bib: 108, Runner: "b", FinishTime: hakone.Seconds(3750), Grade: 2},
+		{Bib: 7, Runner: "c", Note: "DNS", Status: hakone.StatusDNS},
+	}
+}
+
+func TestLoadCorrections(t *testing.T) {
+	dir, err := ioutil.TempDir("", "corrections")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	path := filepath.Join(dir, "corrections.jsonl")
+	content := `{"bib":108,"fields":{"grade":3},"reason":"errata"}
+
+{"place":1,"fields":{"finish_time":3742.5}}
+`
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	corrections, err := LoadCorrections(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(corrections))
+	assert.Equal(t, 108, corrections[0].Bib)
+	assert.Equal(t, "errata", corrections[0].Reason)
+	assert.Equal(t, json.RawMessage("3742.5"), corrections[1].Fields["finish_time"])
+}
+
+func TestLoadCorrections_WithoutKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "corrections")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	path := filepath.Join(dir, "corrections.jsonl")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"fields":{"grade":3}}`), 0644))
+
+	_, err = LoadCorrections(path)
+
+	assert.NotNil(t, err)
+}
+
+func TestApplyCorrections(t *testing.T) {
+	records := correctionRecords()
+	corrections := []Correction{
+		{Bib: 108, Fields: map[string]json.RawMessage{"grade": json.RawMessage("3"), "runner": json.RawMessage(`"b2"`)}, Reason: "errata"},
+		{Place: 1, Fields: map[string]json.RawMessage{"finish_time": json.RawMessage("3742.5")}},
+	}
+
+	patches, err := ApplyCorrections(records, corrections)
+
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.Grade(3), records[1].Grade)
+	assert.Equal(t, hakone.Runner("b2"), records[1].Runner)
+	assert.Equal(t, hakone.Seconds(3742)+500*hakone.Millisecond, records[0].FinishTime)
+	assert.Equal(t, []AppliedPatch{
+		{Bib: 108, Place: 2, Runner: "b2", Field: "grade", Before: json.RawMessage("2"), After: json.RawMessage("3"), Reason: "errata"},
+		{Bib: 108, Place: 2, Runner: "b2", Field: "runner", Before: json.RawMessage(`"b"`), After: json.RawMessage(`"b2"`), Reason: "errata"},
+		{Bib: 42, Place: 1, Runner: "a", Field: "finish_time", Before: json.RawMessage("3743"), After: json.RawMessage("3742.500")},
+	}, patches)
+}
+
+func TestApplyCorrections_NoteUpdatesStatus(t *testing.T) {
+	records := correctionRecords()
+	corrections := []Correction{
+		{Bib: 7, Fields: map[string]json.RawMessage{"Note": json.RawMessage(`""`), "finish_time": json.RawMessage("3900")}},
+	}
+
+	_, err := ApplyCorrections(records, corrections)
+
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.StatusFinished, records[2].Status)
+	assert.Equal(t, hakone.Seconds(3900), records[2].FinishTime)
+}
+
+func TestApplyCorrections_Errors(t *testing.T) {
+	cases := map[string]Correction{
+		"no record":     {Bib: 999, Fields: map[string]json.RawMessage{"grade": json.RawMessage("3")}},
+		"unknown field": {Bib: 42, Fields: map[string]json.RawMessage{"age": json.RawMessage("20")}},
+		"invalid value": {Bib: 42, Fields: map[string]json.RawMessage{"finish_time": json.RawMessage(`"1:02:23"`)}},
+	}
+	for name, correction := range cases {
+		records := correctionRecords()
+
+		_, err := ApplyCorrections(records, []Correction{correction})
+
+		assert.NotNil(t, err, name)
+		assert.Equal(t, correctionRecords(), records, name)
+	}
+}
+
+func TestApplyCorrections_AmbiguousPlace(t *testing.T) {
+	records := append(correctionRecords(), hakone.Record{Place: 2, Bib: 5, Runner: "d"})
+
+	_, err := ApplyCorrections(records, []Correction{{Place: 2, Fields: map[string]json.RawMessage{"grade": json.RawMessage("1")}}})
+
+	assert.NotNil(t, err)
+}
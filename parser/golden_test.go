@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files of the parser")
+
+// TestGolden replays captured pages in testdata/golden/<layout name> and compares records with golden files of each
+// page and of the whole run.
+func TestGolden(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "golden", "*"))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, dirs)
+	for _, dir := range dirs {
+		layout, err := FindLayout(filepath.Base(dir))
+		if !assert.Nil(t, err, dir) {
+			continue
+		}
+		captures, err := filepath.Glob(filepath.Join(dir, "*.texts.jsonl"))
+		assert.Nil(t, err)
+		for _, capture := range captures {
+			t.Run(capture, func(t *testing.T) {
+				testGoldenPage(t, layout, capture)
+			})
+		}
+		t.Run(dir, func(t *testing.T) {
+			testGoldenRun(t, layout, dir)
+		})
+	}
+}
+
+// testGoldenRun runs the parser on every captured page of the directory, so that ranking and the edition are compared
+// with run.golden.jsonl too. Captures are pages of the 96th edition.
+func testGoldenRun(t *testing.T, layout Layout, dir string) {
+	outDir, err := ioutil.TempDir("", "golden")
+	assert.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(outDir)
+	}()
+	output := filepath.Join(outDir, "personal.jsonl")
+	_, err = Run(Config{Edition: 96, InputPath: dir, OutputPath: output, Layout: layout}, nil)
+	assert.Nil(t, err)
+
+	actual, err := ioutil.ReadFile(output)
+	assert.Nil(t, err)
+	golden := filepath.Join(dir, "run.golden.jsonl")
+	if *update {
+		assert.Nil(t, ioutil.WriteFile(golden, actual, 0644))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func testGoldenPage(t *testing.T, layout Layout, capture string) {
+	var pageNum int
+	_, err := fmt.Sscanf(filepath.Base(capture), "page-%d", &pageNum)
+	assert.Nil(t, err)
+	texts, err := ReadCaptureFile(capture)
+	assert.Nil(t, err)
+
+	p := Parser{Layout: layout}
+	records, err := p.ParsePage(pageNum, texts)
+	assert.Nil(t, err)
+
+	var actual bytes.Buffer
+	encoder := json.NewEncoder(&actual)
+	for _, record := range records {
+		assert.Nil(t, encoder.Encode(record))
+	}
+	golden := strings.TrimSuffix(capture, ".texts.jsonl") + ".golden.jsonl"
+	if *update {
+		assert.Nil(t, ioutil.WriteFile(golden, actual.Bytes(), 0644))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), actual.String())
+}
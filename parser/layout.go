@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"sort"
+)
+
+type Extraction int
+
+const (
+	// GlyphChain reads texts glyph by glyph with the chain of Analyzer.
+	GlyphChain Extraction = iota
+	// ColumnTable groups texts into rows and columns by their coordinates.
+	ColumnTable
+)
+
+// Layout is a profile of the personal result sheet which differs by the edition.
+type Layout struct {
+	Name       string
+	Extraction Extraction
+	// HeaderSeparator is a character repeated in the line between the header and records.
+	HeaderSeparator string
+	NoteChars       *regexp.Regexp
+	// NumberGap is the max distance of X axis between digits of the same number on GlyphChain extraction,
+	// digits apart more than it are treated as the place and the bib.
+	NumberGap float64
+	// Headers are labels of the header row used to detect columns on ColumnTable extraction.
+	Headers map[ColumnKey]string
+	// OptionalHeaders are labels of columns which some sheets don't have, like the net time.
+	OptionalHeaders map[ColumnKey]string
+	// RowTolerance is the max difference of Y axis of texts on the same row.
+	RowTolerance float64
+}
+
+// noteChars accepts codes like "DQ2" and Japanese notes like "途中棄権".
+var noteChars = regexp.MustCompile(`^[A-Za-z0-9\p{Han}\p{Katakana}ー]$`)
+
+var DefaultLayout = Layout{
+	Name:            "hakone-96",
+	Extraction:      GlyphChain,
+	HeaderSeparator: "-",
+	NoteChars:       noteChars,
+	NumberGap:       3.0,
+	RowTolerance:    1.0,
+}
+
+var ColumnLayout = Layout{
+	Name:            "hakone-96-columns",
+	Extraction:      ColumnTable,
+	HeaderSeparator: "-",
+	NoteChars:       noteChars,
+	Headers: map[ColumnKey]string{
+		ColumnPlace:  "順位",
+		ColumnBib:    "ナンバー",
+		ColumnRunner: "氏名",
+		ColumnGrade:  "学年",
+		ColumnTeam:   "大学名",
+		Column5km:    "5km",
+		Column10km:   "10km",
+		Column15km:   "15km",
+		Column20km:   "20km",
+		ColumnFinish: "記録",
+		ColumnNote:   "備考",
+	},
+	OptionalHeaders: map[ColumnKey]string{
+		ColumnNetTime: "ネットタイム",
+	},
+	RowTolerance: 1.0,
+}
+
+var layouts = map[string]Layout{
+	DefaultLayout.Name: DefaultLayout,
+	ColumnLayout.Name:  ColumnLayout,
+}
+
+func FindLayout(name string) (Layout, error) {
+	layout, ok := layouts[name]
+	if !ok {
+		return Layout{}, errors.Errorf("unknown layout \"%s\", available layouts: %v", name, LayoutNames())
+	}
+	return layout, nil
+}
+
+func LayoutNames() []string {
+	names := make([]string, 0, len(layouts))
+	for name := range layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
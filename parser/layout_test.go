@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"github.com/ledongthuc/pdf"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindLayout(t *testing.T) {
+	layout, err := FindLayout("hakone-96")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultLayout.Name, layout.Name)
+}
+
+func TestFindLayout_Unknown(t *testing.T) {
+	_, err := FindLayout("hakone-1")
+	assert.NotNil(t, err)
+}
+
+func TestDiscardingHeaderAnalyzer_Take_WithLayout(t *testing.T) {
+	texts := []pdf.Text{
+		makeText(1.0, 2.0, "備"), // 0
+		makeText(3.0, 2.0, "考"),
+		makeText(5.0, 2.0, "="),
+		makeText(7.0, 2.0, "="),
+		makeText(9.0, 2.0, "="),
+		makeText(11.0, 2.0, "1"), // 5
+		makeText(13.0, 2.0, "長"),
+	}
+	layout := DefaultLayout
+	layout.HeaderSeparator = "="
+
+	analyzer := NewAnalyzer(layout)
+
+	s, next, pos := analyzer.Take(0, texts)
+	assert.True(t, s.empty)
+	assert.Equal(t, Position(5), pos)
+	assert.Equal(t, "*PlaceAnalyzer", AnalyzerName(next))
+}
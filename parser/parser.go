@@ -0,0 +1,233 @@
+package parser
+
+import (
+	"encoding/json"
+	"github.com/ledongthuc/pdf"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+)
+
+// Config is a set of inputs to convert a personal result sheet pdf into a jsonl file.
+type Config struct {
+	// Edition is the number of the edition written in records.
+	Edition int
+	// InputPath is the pdf, or a directory of pages captured by CaptureDir.
+	InputPath  string
+	OutputPath string
+	Layout     Layout
+	// Recover skips malformed rows instead of failing, skipped rows are written to ReportPath if not empty.
+	Recover    bool
+	ReportPath string
+	// CorrectionsPath is a jsonl file of corrections applied after parsing, applied patches are written to AuditPath
+	// if not empty.
+	CorrectionsPath string
+	AuditPath       string
+	// CaptureDir is a directory to write texts of each page as fixtures if not empty.
+	CaptureDir string
+}
+
+// Parser keeps inconsistencies of the records found while parsing pages, and rows skipped on the recover mode.
+type Parser struct {
+	Edition         int
+	Layout          Layout
+	Recover         bool
+	CaptureDir      string
+	Inconsistencies []Inconsistency
+	Skipped         []SkippedRow
+}
+
+// Result is the records of the input pdf and problems found in them.
+type Result struct {
+	Records         []hakone.Record
+	Inconsistencies []Inconsistency
+	Skipped         []SkippedRow
+	Patches         []AppliedPatch
+}
+
+// Run parses the input pdf and writes records into the output file, each record is also written to echo if not nil.
+func Run(config Config, echo io.Writer) (*Result, error) {
+	p := Parser{Edition: config.Edition, Layout: config.Layout, Recover: config.Recover, CaptureDir: config.CaptureDir}
+	var records []hakone.Record
+	var err error
+	if info, statErr := os.Stat(config.InputPath); statErr == nil && info.IsDir() {
+		records, err = p.ParseCaptureDir(config.InputPath)
+	} else {
+		records, err = p.ParseFile(config.InputPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	patches, err := correct(config, records)
+	if err != nil {
+		return nil, err
+	}
+	err = WriteJsonlFile(config.OutputPath, records, echo)
+	if err != nil {
+		return nil, err
+	}
+	if config.ReportPath != "" {
+		err = WriteReportFile(config.ReportPath, p.Skipped)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Result{Records: records, Inconsistencies: p.Inconsistencies, Skipped: p.Skipped, Patches: patches}, nil
+}
+
+// correct applies corrections of the config and ranks records again because finish times may be corrected.
+func correct(config Config, records []hakone.Record) ([]AppliedPatch, error) {
+	if config.CorrectionsPath == "" {
+		return nil, nil
+	}
+	corrections, err := LoadCorrections(config.CorrectionsPath)
+	if err != nil {
+		return nil, err
+	}
+	patches, err := ApplyCorrections(records, corrections)
+	if err != nil {
+		return nil, err
+	}
+	rankFinishedRecords(records)
+	if config.AuditPath != "" {
+		err = WriteAuditFile(config.AuditPath, patches)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return patches, nil
+}
+
+func (p *Parser) ParseFile(path string) ([]hakone.Record, error) {
+	file, reader, err := pdf.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	maxPageNum := reader.NumPage()
+	pages := make([][]pdf.Text, 0, maxPageNum)
+	for pageNum := 1; pageNum <= maxPageNum; pageNum++ {
+		pages = append(pages, reader.Page(pageNum).Content().Text)
+	}
+	return p.ParsePages(pages)
+}
+
+// ParseCaptureDir parses pages captured in the directory instead of the pdf.
+func (p *Parser) ParseCaptureDir(dir string) ([]hakone.Record, error) {
+	pages, err := ReadCaptureDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return p.ParsePages(pages)
+}
+
+// ParsePages parses texts of pages from the first page, records are given the edition and ranked by finish times.
+func (p *Parser) ParsePages(pages [][]pdf.Text) ([]hakone.Record, error) {
+	records := make([]hakone.Record, 0)
+	for index, texts := range pages {
+		pageNum := index + 1
+		if err := p.capture(pageNum, texts); err != nil {
+			return records, err
+		}
+		recs, err := p.ParsePage(pageNum, texts)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, recs...)
+	}
+
+	for index := range records {
+		records[index].Edition = p.Edition
+	}
+	rankFinishedRecords(records)
+	return records, nil
+}
+
+func (p *Parser) ParsePage(pageNum int, texts []pdf.Text) ([]hakone.Record, error) {
+	if p.Layout.Extraction == ColumnTable {
+		return p.parsePageByColumns(pageNum, texts)
+	}
+	records := make([]hakone.Record, 0)
+
+	analyzer := NewAnalyzer(p.Layout)
+	position := StartPosition()
+
+	_, analyzer, position = analyzer.Take(position, texts) // discard header -> runner-name
+
+	for i := 0; ; i++ {
+		res, err := NextRecord(analyzer, position, texts)
+		if err != nil && p.Recover {
+			next := p.nextRowStart(position, texts)
+			p.skipRow(pageNum, position, next, texts, err)
+			if next.isOutOfRangeOf(texts) {
+				break
+			}
+			position = next
+			continue
+		}
+		if err != nil {
+			return records, errors.Wrapf(err, "failed to load new record at page: %d, index: %d, position: %v", pageNum, i, position)
+		}
+		records = append(records, res.Record)
+		p.check(Source{Page: pageNum, Position: position}, res.Record)
+		position = res.Position
+		if res.Done {
+			break
+		}
+	}
+	return records, nil
+}
+
+func WriteJsonlFile(path string, records []hakone.Record, echo io.Writer) error {
+	jsonFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open result file %s", path)
+	}
+	defer func() {
+		_ = jsonFile.Close()
+	}()
+	encoder := json.NewEncoder(jsonFile)
+	for _, rec := range records {
+		if err := encoder.Encode(rec); err != nil {
+			return errors.Wrapf(err, "failed to write record %v", rec)
+		}
+		if echo != nil {
+			_ = json.NewEncoder(echo).Encode(rec)
+			_, _ = io.WriteString(echo, "\n")
+		}
+	}
+	return nil
+}
+
+func (p *Parser) parsePageByColumns(pageNum int, texts []pdf.Text) ([]hakone.Record, error) {
+	table, err := ExtractTable(texts, p.Layout)
+	if err != nil && p.Recover {
+		p.Skipped = append(p.Skipped, SkippedRow{Page: pageNum, Error: err.Error()})
+		return make([]hakone.Record, 0), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to extract table at page: %d", pageNum)
+	}
+	records := make([]hakone.Record, 0)
+	for i, rows := range GroupRecordRows(table.Rows) {
+		record, err := BuildRecord(rows)
+		if err != nil && p.Recover {
+			p.Skipped = append(p.Skipped, SkippedRow{Page: pageNum, Row: i + 1, Text: rows.String(), Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return records, errors.Wrapf(err, "failed to build record at page: %d, index: %d", pageNum, i)
+		}
+		records = append(records, record)
+		p.check(Source{Page: pageNum, Row: i + 1}, record)
+	}
+	return records, nil
+}
+
+func (p *Parser) check(source Source, record hakone.Record) {
+	p.Inconsistencies = append(p.Inconsistencies, inconsistenciesOf(source, record)...)
+}
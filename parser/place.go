@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"sort"
+)
+
+// rankFinishedRecords gives ranks computed from finish times to scored runners, runners with the same time share
+// the same rank. Other runners have zero.
+func rankFinishedRecords(records []hakone.Record) {
+	indexes := make([]int, 0, len(records))
+	for idx := range records {
+		records[idx].Order = 0
+		if records[idx].IsScored() {
+			indexes = append(indexes, idx)
+		}
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return records[indexes[i]].FinishTime < records[indexes[j]].FinishTime
+	})
+	for i, idx := range indexes {
+		rank := i + 1
+		if i > 0 {
+			prev := records[indexes[i-1]]
+			if prev.FinishTime == records[idx].FinishTime {
+				rank = prev.Order
+			}
+		}
+		records[idx].Order = rank
+	}
+}
+
+// PlaceMismatch is a record whose official place differs from the computed rank.
+type PlaceMismatch struct {
+	Record hakone.Record
+}
+
+func (pm PlaceMismatch) String() string {
+	return fmt.Sprintf("place mismatch: %s(%s) place: %d, rank: %d, finish: %s",
+		pm.Record.Runner, pm.Record.Team, pm.Record.Place, pm.Record.Order, pm.Record.FinishTime)
+}
+
+// ValidatePlaces reports records whose official place and computed rank are not same.
+func ValidatePlaces(records []hakone.Record) []PlaceMismatch {
+	mismatches := make([]PlaceMismatch, 0)
+	for _, record := range records {
+		if record.Place != record.Order {
+			mismatches = append(mismatches, PlaceMismatch{Record: record})
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRankFinishedRecords(t *testing.T) {
+	records := []hakone.Record{
+		{Runner: "a", Place: 1, FinishTime: hakone.Seconds(3600)},
+		{Runner: "b", Place: 2, FinishTime: hakone.Seconds(3610)},
+		{Runner: "c", Status: hakone.StatusDNF, Order: 5},
+		{Runner: "d", Place: 3, FinishTime: hakone.Seconds(3610)},
+		{Runner: "e", Place: 4, FinishTime: hakone.Seconds(3620)},
+		{Runner: "f", Status: hakone.StatusOpen, FinishTime: hakone.Seconds(3605)},
+	}
+
+	rankFinishedRecords(records)
+
+	assert.Equal(t, 1, records[0].Order)
+	assert.Equal(t, 2, records[1].Order)
+	assert.Equal(t, 0, records[2].Order)
+	assert.Equal(t, 2, records[3].Order)
+	assert.Equal(t, 4, records[4].Order)
+	assert.Equal(t, 0, records[5].Order)
+}
+
+func TestValidatePlaces(t *testing.T) {
+	records := []hakone.Record{
+		{Runner: "a", Place: 1, FinishTime: hakone.Seconds(3600)},
+		{Runner: "b", Place: 2, FinishTime: hakone.Seconds(3610)},
+		{Runner: "d", Place: 3, FinishTime: hakone.Seconds(3610)},
+		{Runner: "e", Place: 4, FinishTime: hakone.Seconds(3620)},
+		{Runner: "f", Status: hakone.StatusDNS},
+	}
+	rankFinishedRecords(records)
+
+	mismatches := ValidatePlaces(records)
+
+	assert.Equal(t, 1, len(mismatches))
+	if len(mismatches) != 1 {
+		return
+	}
+	assert.Equal(t, hakone.Runner("d"), mismatches[0].Record.Runner)
+	assert.Equal(t, 3, mismatches[0].Record.Place)
+	assert.Equal(t, 2, mismatches[0].Record.Order)
+}
+
+func TestPlaceMismatch_String(t *testing.T) {
+	mismatch := PlaceMismatch{Record: hakone.Record{Runner: "d", Team: "東洋大", Place: 3, Order: 2, FinishTime: hakone.Seconds(3610)}}
+
+	assert.Equal(t, "place mismatch: d(東洋大) place: 3, rank: 2, finish: 1:00:10", mismatch.String())
+}
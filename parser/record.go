@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/ledongthuc/pdf"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+	"reflect"
+	"strconv"
+)
+
+type LoadResult struct {
+	Record   hakone.Record
+	Position Position
+	Done     bool
+}
+
+func NextRecord(analyzer Analyzer, position Position, texts []pdf.Text) (*LoadResult, error) {
+	if position.isOutOfRangeOf(texts) {
+		result := LoadResult{Record: hakone.Record{}, Position: Position(len(texts)), Done: true}
+		return &result, errors.New("already done")
+	}
+	an := analyzer
+	pos := position
+	placeStr, an, pos := an.Take(pos, texts)
+	bibStr, an, pos := an.Take(pos, texts)
+	runnerName, an, pos := an.Take(pos, texts)
+	grade, an, pos := an.Take(pos, texts)
+	team, an, pos := an.Take(pos, texts)
+
+	var times Times
+	t, an, pos := an.Take(pos, texts) // 5km
+	if t != emptyStr {
+		time, err := hakone.NewTime(t.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format time of TimeOf5km(%s) at %v", t.value, pos))
+		}
+		times.TimeOf5km = time
+		t = emptyStr
+	}
+	if _, ok := an.(*NoteAnalyzer); ok == false {
+		tim, a, p := an.Take(pos, texts) // 10km
+		t = tim
+		an = a
+		pos = p
+	}
+	if t != emptyStr {
+		time, err := hakone.NewTime(t.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format time of TimeOf10km(%s) at %v", t.value, pos))
+		}
+		times.TimeOf10km = time
+		t = emptyStr
+	}
+	if _, ok := an.(*NoteAnalyzer); ok == false {
+		tim, a, p := an.Take(pos, texts) // 15km
+		t = tim
+		an = a
+		pos = p
+	}
+	if t != emptyStr {
+		time, err := hakone.NewTime(t.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format time of TimeOf15km(%s) at %v", t.value, pos))
+		}
+		times.TimeOf15km = time
+		t = emptyStr
+	}
+	if _, ok := an.(*NoteAnalyzer); ok == false {
+		tim, a, p := an.Take(pos, texts) // 20km
+		t = tim
+		an = a
+		pos = p
+	}
+	if t != emptyStr {
+		time, err := hakone.NewTime(t.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format time of TimeOf20km(%s) at %v", t.value, pos))
+		}
+		times.TimeOf20km = time
+		t = emptyStr
+	}
+	if _, ok := an.(*NoteAnalyzer); ok == false {
+		tim, a, p := an.Take(pos, texts) // half
+		t = tim
+		an = a
+		pos = p
+	}
+	if t != emptyStr {
+		time, err := hakone.NewTime(t.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format time of TimeOfFinish(%s) at %v", t.value, pos))
+		}
+		times.TimeOfFinish = time
+		t = emptyStr
+	}
+
+	var note Str
+	if _, ok := an.(*NoteAnalyzer); ok { // note
+		n, a, p := an.Take(pos, texts)
+		note = n
+		an = a
+		pos = p
+	}
+	romanizedName, an, pos := an.Take(pos, texts)
+	nationality, an, pos := an.Take(pos, texts)
+
+	var rap Raps
+	rapTime := emptyStr
+	if _, ok := an.(*RapTo10kmAnalyzer); ok {
+		rt, a, p := an.Take(pos, texts)
+		rapTime = rt
+		an = a
+		pos = p
+	}
+	if rapTime != emptyStr {
+		rt, err := hakone.NewTime(rapTime.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format rap time of RapTo10km(%s) at %v", rapTime.value, pos))
+		}
+		rap.RapTo10km = rt
+		rapTime = emptyStr
+	}
+	if _, ok := an.(*RapTo15kmAnalyzer); ok {
+		rt, a, p := an.Take(pos, texts)
+		rapTime = rt
+		an = a
+		pos = p
+	}
+	if rapTime != emptyStr {
+		rt, err := hakone.NewTime(rapTime.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format rap time of RapTo15km(%s) at %v", rapTime.value, pos))
+		}
+		rap.RapTo15km = rt
+		rapTime = emptyStr
+	}
+	if _, ok := an.(*RapTo20kmAnalyzer); ok {
+		rt, a, p := an.Take(pos, texts)
+		rapTime = rt
+		an = a
+		pos = p
+	}
+	if rapTime != emptyStr {
+		rt, err := hakone.NewTime(rapTime.value)
+		if err != nil {
+			return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid format rap time of RapTo20km(%s) at %v", rapTime.value, pos))
+		}
+		rap.RapTo20km = rt
+		rapTime = emptyStr
+	}
+
+	_, finished := an.(*DoneAnalyzer)
+	_, succeeded := an.(*PlaceAnalyzer)
+	if !finished && !succeeded {
+		var d DefaultAnalyzer
+		current, _, _ := d.Take(pos, texts)
+		return &LoadResult{}, recordError(an, pos, errors.New(
+			fmt.Sprintf("invalid finish status at position: %v(%v), analyzer: %s(%v)", pos, current, AnalyzerName(an), an)))
+	}
+
+	place, err := parsePlace(placeStr.value)
+	if err != nil {
+		return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "at %v", pos))
+	}
+	bib, err := parseBib(bibStr.value)
+	if err != nil {
+		return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "at %v", pos))
+	}
+	g, err := hakone.NewGrade(grade.value)
+	if err != nil {
+		return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "invalid grade of %s at %v", runnerName.value, pos))
+	}
+
+	record := hakone.Record{
+		Place:             place,
+		Bib:               bib,
+		Runner:            hakone.Runner(runnerName.value),
+		RomanizedName:     romanizedName.value,
+		Nationality:       nationality.value,
+		Grade:             g,
+		Team:              hakone.TeamName(team.value),
+		TimeOf5km:         times.TimeOf5km,
+		TimeOf10km:        times.TimeOf10km,
+		TimeOf15km:        times.TimeOf15km,
+		TimeOf20km:        times.TimeOf20km,
+		FinishTime:        times.TimeOfFinish,
+		RapFrom5kmTo10km:  rap.RapTo10km,
+		RapFrom10kmTo15km: rap.RapTo15km,
+		RapFrom15kmTo20km: rap.RapTo20km,
+		Note:              hakone.Note(note.value),
+	}
+	status, err := statusOf(record)
+	if err != nil {
+		return &LoadResult{}, recordError(an, pos, errors.Wrapf(err, "at %v", pos))
+	}
+	record.Status = status
+	// rows of runners who did not finish have no place, so the only number of the row is the bib.
+	if bibStr == emptyStr && !record.Status.Finished() {
+		record.Place, record.Bib = 0, record.Place
+	}
+
+	result := LoadResult{Record: record, Position: pos, Done: finished}
+
+	return &result, nil
+}
+
+func parsePlace(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	place, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid place(%s)", value)
+	}
+	return place, nil
+}
+
+func parseBib(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	bib, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid bib(%s)", value)
+	}
+	return bib, nil
+}
+
+func AnalyzerName(a interface{}) string {
+	if t := reflect.TypeOf(a); t.Kind() == reflect.Ptr {
+		return fmt.Sprintf("*%s", t.Elem().Name())
+	} else {
+		return t.Name()
+	}
+}
+
+type Times struct {
+	TimeOf5km    hakone.Time
+	TimeOf10km   hakone.Time
+	TimeOf15km   hakone.Time
+	TimeOf20km   hakone.Time
+	TimeOfFinish hakone.Time
+}
+
+type Raps struct {
+	RapTo10km hakone.Time
+	RapTo15km hakone.Time
+	RapTo20km hakone.Time
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// RecordRows are rows of a runner, the main row has the place and times, the sub row has raps in parentheses.
+type RecordRows struct {
+	Main Row
+	Sub  *Row
+}
+
+func (rr RecordRows) String() string {
+	if rr.Sub == nil {
+		return rr.Main.String()
+	}
+	return rr.Main.String() + " " + rr.Sub.String()
+}
+
+// GroupRecordRows starts a new record at a row which has a place or a grade, other rows are sub rows of the previous one.
+func GroupRecordRows(rows []Row) []RecordRows {
+	result := make([]RecordRows, 0)
+	for _, row := range rows {
+		if row.Cell(ColumnPlace) != "" || row.Cell(ColumnGrade) != "" || len(result) == 0 {
+			result = append(result, RecordRows{Main: row})
+			continue
+		}
+		last := &result[len(result)-1]
+		if last.Sub == nil {
+			sub := row
+			last.Sub = &sub
+		}
+	}
+	return result
+}
+
+func BuildRecord(rows RecordRows) (hakone.Record, error) {
+	main := rows.Main
+	record := hakone.Record{
+		Runner: hakone.Runner(main.Cell(ColumnRunner)),
+		Team:   hakone.TeamName(main.Cell(ColumnTeam)),
+		Note:   hakone.Note(main.Cell(ColumnNote)),
+	}
+	if value := main.Cell(ColumnGrade); value != "" {
+		grade, err := hakone.NewGrade(value)
+		if err != nil {
+			return record, errors.Wrapf(err, "grade of %s", record.Runner)
+		}
+		record.Grade = grade
+	}
+	place, err := parsePlace(main.Cell(ColumnPlace))
+	if err != nil {
+		return record, errors.Wrapf(err, "place of %s", record.Runner)
+	}
+	record.Place = place
+	bib, err := parseBib(main.Cell(ColumnBib))
+	if err != nil {
+		return record, errors.Wrapf(err, "bib of %s", record.Runner)
+	}
+	record.Bib = bib
+
+	times := []struct {
+		key   ColumnKey
+		field *hakone.Time
+	}{
+		{Column5km, &record.TimeOf5km},
+		{Column10km, &record.TimeOf10km},
+		{Column15km, &record.TimeOf15km},
+		{Column20km, &record.TimeOf20km},
+		{ColumnFinish, &record.FinishTime},
+		{ColumnNetTime, &record.NetTime},
+	}
+	for _, t := range times {
+		if err := timeCell(main, t.key, t.field); err != nil {
+			return record, errors.Wrapf(err, "invalid time of %s", record.Runner)
+		}
+	}
+
+	status, err := statusOf(record)
+	if err != nil {
+		return record, err
+	}
+	record.Status = status
+
+	if rows.Sub == nil {
+		return record, nil
+	}
+	record.RomanizedName = rows.Sub.Cell(ColumnRunner)
+	record.Nationality = rows.Sub.Cell(ColumnTeam)
+	raps := []struct {
+		key   ColumnKey
+		field *hakone.Time
+	}{
+		{Column10km, &record.RapFrom5kmTo10km},
+		{Column15km, &record.RapFrom10kmTo15km},
+		{Column20km, &record.RapFrom15kmTo20km},
+	}
+	for _, r := range raps {
+		if err := timeCell(*rows.Sub, r.key, r.field); err != nil {
+			return record, errors.Wrapf(err, "invalid rap time of %s", record.Runner)
+		}
+	}
+	return record, nil
+}
+
+func timeCell(row Row, key ColumnKey, field *hakone.Time) error {
+	value := strings.Trim(row.Cell(key), "()")
+	if value == "" {
+		return nil
+	}
+	time, err := hakone.NewTime(value)
+	if err != nil {
+		return err
+	}
+	*field = time
+	return nil
+}
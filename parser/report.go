@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"encoding/json"
+	"github.com/ledongthuc/pdf"
+	"github.com/pkg/errors"
+	"math"
+	"os"
+	"strings"
+)
+
+// RecordError is an error of NextRecord with the state of the analyzer chain where the record failed.
+type RecordError struct {
+	Position Position
+	Analyzer string
+	cause    error
+}
+
+func recordError(an Analyzer, pos Position, err error) error {
+	return &RecordError{Position: pos, Analyzer: AnalyzerName(an), cause: err}
+}
+
+func (e *RecordError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *RecordError) Cause() error {
+	return e.cause
+}
+
+// SkippedRow is a malformed row skipped on the recover mode. Position is the start of the row and FailedAt is where
+// the analyzer failed in the glyph chain layout, Row is the number of the row in the page from 1 in the column table
+// layout. Text is the text from the start of the row to the next row.
+type SkippedRow struct {
+	Page     int      `json:"page"`
+	Position Position `json:"position"`
+	Row      int      `json:"row,omitempty"`
+	FailedAt Position `json:"failed_at,omitempty"`
+	Analyzer string   `json:"analyzer,omitempty"`
+	Text     string   `json:"text"`
+	Error    string   `json:"error"`
+}
+
+// maxSurroundingTexts limits the text of the skipped row.
+const maxSurroundingTexts = 400
+
+func (p *Parser) skipRow(page int, start, next Position, texts []pdf.Text, err error) {
+	row := SkippedRow{
+		Page:     page,
+		Position: start,
+		Text:     p.surroundingText(start, next, texts),
+		Error:    err.Error(),
+	}
+	if re, ok := err.(*RecordError); ok {
+		row.FailedAt = re.Position
+		row.Analyzer = re.Analyzer
+	}
+	p.Skipped = append(p.Skipped, row)
+}
+
+func (p *Parser) surroundingText(start, end Position, texts []pdf.Text) string {
+	if end > start+maxSurroundingTexts {
+		end = start + maxSurroundingTexts
+	}
+	analyzer := DefaultAnalyzer{layout: &p.Layout}
+	var builder strings.Builder
+	var last Str
+	for pos := start; pos < end && !pos.isOutOfRangeOf(texts); {
+		current, _, next := analyzer.Take(pos, texts)
+		if builder.Len() > 0 && (current.yAxis != last.yAxis || current.xAxis-last.xAxis >= p.Layout.NumberGap) {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(current.value)
+		last = current
+		pos = next
+	}
+	return builder.String()
+}
+
+// nextRowStart finds the first text of a line below the row which starts with a digit, it is the place or the bib of
+// the next record.
+func (p *Parser) nextRowStart(start Position, texts []pdf.Text) Position {
+	if start.isOutOfRangeOf(texts) {
+		return Position(len(texts))
+	}
+	tolerance := p.Layout.RowTolerance
+	startY := texts[start].Y
+	for i := int(start) + 1; i < len(texts); i++ {
+		current := texts[i]
+		if math.Abs(current.Y-startY) <= tolerance || math.Abs(current.Y-texts[i-1].Y) <= tolerance {
+			continue
+		}
+		if str(current).isNumber() {
+			return Position(i)
+		}
+	}
+	return Position(len(texts))
+}
+
+func WriteReportFile(path string, skipped []SkippedRow) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open report file %s", path)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	encoder := json.NewEncoder(file)
+	for _, row := range skipped {
+		if err := encoder.Encode(row); err != nil {
+			return errors.Wrapf(err, "failed to write skipped row %v", row)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"github.com/ledongthuc/pdf"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func chainRecord(y float64, place, bib, name, grade, team, romanized string) []pdf.Text {
+	return line(
+		glyphs(1.0, y, place),
+		glyphs(6.0, y, bib),
+		glyphs(11.0, y, name),
+		glyphs(23.0, y, grade),
+		glyphs(29.0, y, team),
+		glyphs(44.0, y, "14:47"),
+		glyphs(54.0, y, "29:29"),
+		glyphs(64.0, y, "44:20"),
+		glyphs(74.0, y, "59:09"),
+		glyphs(83.0, y, "1:02:23"),
+		glyphs(11.0, y-2.0, romanized),
+		glyphs(29.0, y-2.0, "日本"),
+		glyphs(53.0, y-2.0, "(14:42)"),
+		glyphs(63.0, y-2.0, "(14:51)"),
+		glyphs(73.0, y-2.0, "(14:49)"),
+	)
+}
+
+func chainPage(secondGrade string) []pdf.Text {
+	return line(
+		glyphs(1.0, 80.0, "順位"),
+		glyphs(1.0, 78.0, "----------"),
+		chainRecord(70.0, "1", "42", "山田太郎", "(3)", "東洋大", "YAMADA"),
+		chainRecord(60.0, "2", "108", "鈴木次郎", secondGrade, "東海大", "SUZUKI"),
+		chainRecord(50.0, "3", "7", "佐藤三郎", "(2)", "中央大", "SATO"),
+	)
+}
+
+func TestParser_ParsePage_FailsOnMalformedRow(t *testing.T) {
+	p := Parser{Layout: DefaultLayout}
+
+	_, err := p.ParsePage(2, chainPage("(9)"))
+
+	assert.NotNil(t, err)
+	assert.Empty(t, p.Skipped)
+}
+
+func TestParser_ParsePage_Recover(t *testing.T) {
+	p := Parser{Layout: DefaultLayout, Recover: true}
+
+	records, err := p.ParsePage(2, chainPage("(9)"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+	if len(records) != 2 {
+		return
+	}
+	assert.Equal(t, 1, records[0].Place)
+	assert.Equal(t, 3, records[1].Place)
+	assert.Equal(t, "SATO", records[1].RomanizedName)
+
+	assert.Equal(t, 1, len(p.Skipped))
+	if len(p.Skipped) != 1 {
+		return
+	}
+	skipped := p.Skipped[0]
+	assert.Equal(t, 2, skipped.Page)
+	assert.Equal(t, "*PlaceAnalyzer", skipped.Analyzer)
+	assert.True(t, skipped.Position < skipped.FailedAt)
+	assert.Equal(t, "2 108 鈴木次郎 (9) 東海大 14:47 29:29 44:20 59:09 1:02:23 SUZUKI 日本 (14:42) (14:51) (14:49)", skipped.Text)
+	assert.Contains(t, skipped.Error, "invalid grade of 鈴木次郎")
+}
+
+func TestParser_ParsePage_RecoverWithoutValidRows(t *testing.T) {
+	p := Parser{Layout: DefaultLayout, Recover: true}
+	page := line(
+		glyphs(1.0, 80.0, "順位"),
+		glyphs(1.0, 78.0, "----------"),
+		chainRecord(70.0, "1", "42", "山田太郎", "(8)", "東洋大", "YAMADA"),
+	)
+
+	records, err := p.ParsePage(1, page)
+
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 1, len(p.Skipped))
+}
+
+func TestParser_ParsePage_RecoverColumns(t *testing.T) {
+	page := tablePage()
+	for i, text := range page {
+		// the grade (3) of the first runner is broken into (8)
+		if text.X == 24.0 && text.Y == 70.0 && text.S == "3" {
+			page[i].S = "8"
+		}
+	}
+	p := Parser{Layout: ColumnLayout, Recover: true}
+
+	records, err := p.ParsePage(1, page)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, []SkippedRow{{
+		Page:  1,
+		Row:   1,
+		Text:  "1 42 J.MWANGI2 (8) 東京国際大 14:47 29:29 44:20 59:09 1:02:23 MWANGI ケニア (14:42) (14:51) (14:49)",
+		Error: "grade of J.MWANGI2: grade out of range: (8)",
+	}}, p.Skipped)
+}
+
+func TestParser_nextRowStart_Tolerance(t *testing.T) {
+	p := Parser{Layout: DefaultLayout}
+	texts := line(
+		glyphs(1.0, 70.0, "1"),
+		glyphs(6.0, 70.0, "42"),
+		glyphs(11.0, 70.0, "山田太郎"),
+		glyphs(44.0, 70.4, "14:47"),
+		glyphs(1.0, 60.0, "2"),
+	)
+
+	next := p.nextRowStart(Position(0), texts)
+
+	assert.Equal(t, "2", texts[next].S)
+}
+
+func TestParser_ParsePage_RecoverUnknownNote(t *testing.T) {
+	page := tablePage()
+	for i, text := range page {
+		if text.Y == 60.0 && text.S == "D" {
+			page[i].S = "X"
+		}
+	}
+	p := Parser{Layout: ColumnLayout, Recover: true}
+
+	records, err := p.ParsePage(1, page)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, 1, len(p.Skipped))
+	if len(p.Skipped) != 1 {
+		return
+	}
+	assert.Equal(t, 2, p.Skipped[0].Row)
+	assert.Contains(t, p.Skipped[0].Error, "unknown note code: XNS")
+}
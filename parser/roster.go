@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/ledongthuc/pdf"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+// rosterTokenGap is the min space between texts which separates tokens in a line of the entry list.
+const rosterTokenGap = 2.0
+
+// rosterRowTolerance is the max difference of Y axis of texts in the same line of the entry list.
+const rosterRowTolerance = 1.0
+
+// RosterParser reads entry lists, a line with "大学" starts a team and following lines with a grade are entrants of
+// the team. Teams in the registry have canonical ids, other teams are given ids after them by the order of appearance
+// and added to the registry.
+type RosterParser struct {
+	title    string
+	edition  int
+	registry *hakone.TeamRegistry
+	Rosters  []hakone.Roster
+}
+
+func NewRosterParser(edition hakone.Edition, registry *hakone.TeamRegistry) *RosterParser {
+	return &RosterParser{
+		title:    fmt.Sprintf("第%d回", edition.Number),
+		edition:  edition.Number,
+		registry: registry,
+		Rosters:  make([]hakone.Roster, 0),
+	}
+}
+
+func (rp *RosterParser) ParsePage(texts []pdf.Text) error {
+	for _, line := range GroupLines(validTexts(texts), rosterRowTolerance) {
+		tokens := rosterTokens(line)
+		text := strings.Join(tokens, "")
+		if rp.isTeamLine(text) {
+			team, err := rp.resolve(text)
+			if err != nil {
+				return err
+			}
+			rp.Rosters = append(rp.Rosters, hakone.Roster{Team: team, Entrants: make([]hakone.Entrant, 0)})
+			continue
+		}
+		if len(rp.Rosters) == 0 {
+			continue
+		}
+		if entrant, ok := parseEntrant(tokens); ok {
+			roster := &rp.Rosters[len(rp.Rosters)-1]
+			roster.Entrants = append(roster.Entrants, entrant)
+		}
+	}
+	return nil
+}
+
+func (rp *RosterParser) isTeamLine(text string) bool {
+	return strings.Contains(text, "大学") &&
+		!strings.Contains(text, rp.title) &&
+		!strings.Contains(text, "人数")
+}
+
+func (rp *RosterParser) resolve(name string) (hakone.Team, error) {
+	if team, ok := rp.registry.Resolve(name); ok {
+		return team.InEdition(rp.edition), nil
+	}
+	team := hakone.Team{Id: rp.registry.NextId(), Name: name}
+	if err := rp.registry.Add(team); err != nil {
+		return team, errors.Wrapf(err, "failed to add team %s", name)
+	}
+	return team.InEdition(rp.edition), nil
+}
+
+func (rp *RosterParser) Teams() []hakone.Team {
+	teams := make([]hakone.Team, len(rp.Rosters))
+	for index, roster := range rp.Rosters {
+		teams[index] = roster.Team
+	}
+	return teams
+}
+
+func rosterTokens(line []pdf.Text) []string {
+	tokens := make([]string, 0)
+	var builder strings.Builder
+	for index, text := range line {
+		if index > 0 {
+			prev := line[index-1]
+			if text.X-(prev.X+prev.W) >= rosterTokenGap {
+				tokens = append(tokens, builder.String())
+				builder.Reset()
+			}
+		}
+		builder.WriteString(text.S)
+	}
+	if builder.Len() > 0 {
+		tokens = append(tokens, builder.String())
+	}
+	return tokens
+}
+
+// parseEntrant reads tokens of number, name, grade and personal bests, the number is optional and the name may be
+// separated by a space. Personal bests are told by the number of colons, tokens which are not time are ignored.
+func parseEntrant(tokens []string) (hakone.Entrant, bool) {
+	index := 0
+	for index < len(tokens) && isNumberToken(tokens[index]) {
+		index++
+	}
+	names := make([]string, 0)
+	var entrant hakone.Entrant
+	for ; index < len(tokens); index++ {
+		grade, err := hakone.NewGrade(tokens[index])
+		if err == nil && len(names) > 0 {
+			entrant.Grade = grade
+			break
+		}
+		names = append(names, tokens[index])
+	}
+	if entrant.Grade == 0 {
+		return entrant, false
+	}
+	entrant.Runner = hakone.Runner(strings.Join(names, ""))
+	for _, token := range tokens[index+1:] {
+		t, err := hakone.NewTime(token)
+		if err != nil {
+			continue
+		}
+		if strings.Count(token, ":") == 1 && entrant.Best10000m == 0 {
+			entrant.Best10000m = t
+		} else if strings.Count(token, ":") == 2 && entrant.BestHalf == 0 {
+			entrant.BestHalf = t
+		}
+	}
+	return entrant, true
+}
+
+func isNumberToken(token string) bool {
+	_, err := strconv.Atoi(token)
+	return err == nil
+}
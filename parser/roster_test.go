@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"github.com/ledongthuc/pdf"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func rosterPage() []pdf.Text {
+	return line(
+		glyphs(1.0, 90.0, "第96回東京箱根間往復大学駅伝競走予選会"),
+		glyphs(1.0, 85.0, "参加人数"),
+		glyphs(1.0, 80.0, "東京国際大学"),
+		glyphs(1.0, 75.0, "1"),
+		glyphs(5.0, 75.0, "J.MWANGI"),
+		glyphs(20.0, 75.0, "(3)"),
+		glyphs(26.0, 75.0, "27:47.26"),
+		glyphs(38.0, 75.0, "1:01:30"),
+		glyphs(1.0, 70.0, "2"),
+		glyphs(5.0, 70.0, "山田"),
+		glyphs(8.0, 70.0, "太郎"),
+		glyphs(20.0, 70.0, "(1)"),
+		glyphs(26.0, 70.0, "29:10.05"),
+		glyphs(38.0, 70.0, "-"),
+		glyphs(1.0, 65.0, "箱根大学"),
+		glyphs(1.0, 60.0, "東洋大学"),
+		glyphs(1.0, 55.0, "11"),
+		glyphs(5.0, 55.0, "鈴木次郎"),
+		glyphs(20.0, 55.3, "(4)"),
+		glyphs(38.0, 55.0, "1:03:10"),
+	)
+}
+
+func TestRosterParser_ParsePage(t *testing.T) {
+	registry, _ := hakone.NewTeamRegistry([]hakone.Team{
+		{Id: 6, Name: "東京国際大学", ShortName: "東京国際大"},
+		{Id: 2, Name: "東洋大学", ShortName: "東洋大"},
+	})
+	rp := NewRosterParser(hakone.NewEdition(96), registry)
+
+	assert.Nil(t, rp.ParsePage(rosterPage()))
+
+	assert.Equal(t, []hakone.Team{
+		{Id: 6, Edition: 96, Name: "東京国際大学", ShortName: "東京国際大"},
+		{Id: 7, Edition: 96, Name: "箱根大学"},
+		{Id: 2, Edition: 96, Name: "東洋大学", ShortName: "東洋大"},
+	}, rp.Teams())
+	team, ok := registry.Resolve("箱根大学")
+	assert.True(t, ok)
+	assert.Equal(t, hakone.Team{Id: 7, Name: "箱根大学"}, team)
+	assert.Empty(t, rp.Rosters[1].Entrants)
+	assert.Equal(t, []hakone.Entrant{
+		{
+			Runner:     "J.MWANGI",
+			Grade:      3,
+			Best10000m: hakone.Seconds(27*60+47) + 260*hakone.Millisecond,
+			BestHalf:   hakone.Hours(1) + hakone.Seconds(90),
+		},
+		{Runner: "山田太郎", Grade: 1, Best10000m: hakone.Seconds(29*60+10) + 50*hakone.Millisecond},
+	}, rp.Rosters[0].Entrants)
+	assert.Equal(t, []hakone.Entrant{
+		{Runner: "鈴木次郎", Grade: 4, BestHalf: hakone.Hours(1) + hakone.Seconds(190)},
+	}, rp.Rosters[2].Entrants)
+}
+
+func TestParseEntrant_WithoutGrade(t *testing.T) {
+	_, ok := parseEntrant([]string{"12", "山田太郎", "29:10.05"})
+	assert.False(t, ok)
+}
+
+func TestParseEntrant_BareGrade(t *testing.T) {
+	entrant, ok := parseEntrant([]string{"12", "山田太郎", "2", "29:10.05"})
+	assert.True(t, ok)
+	assert.Equal(t, hakone.Grade(2), entrant.Grade)
+	assert.Equal(t, hakone.Runner("山田太郎"), entrant.Runner)
+}
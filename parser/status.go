@@ -0,0 +1,19 @@
+package parser
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/pkg/errors"
+)
+
+// statusOf decides the status from the note code, runners without finish time are treated as DNF unless the note says.
+// It fails on an unknown note code instead of guessing the status.
+func statusOf(record hakone.Record) (hakone.Status, error) {
+	status, err := record.Note.Status()
+	if err != nil {
+		return status, errors.Wrapf(err, "invalid note of %s", record.Runner)
+	}
+	if status == hakone.StatusFinished && record.FinishTime == 0 {
+		return hakone.StatusDNF, nil
+	}
+	return status, nil
+}
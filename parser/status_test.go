@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStatusOf(t *testing.T) {
+	for expected, record := range map[hakone.Status]hakone.Record{
+		hakone.StatusFinished: {FinishTime: hakone.Seconds(3600)},
+		hakone.StatusDNF:      {TimeOf5km: hakone.Seconds(900)},
+		hakone.StatusDNS:      {Note: "DNS"},
+		hakone.StatusDQ:       {TimeOf5km: hakone.Seconds(900), Note: "DQ2"},
+		hakone.StatusOpen:     {FinishTime: hakone.Seconds(3600), Note: "OP"},
+	} {
+		status, err := statusOf(record)
+		assert.Nil(t, err, record.Note)
+		assert.Equal(t, expected, status, record.Note)
+	}
+	status, err := statusOf(hakone.Record{TimeOf5km: hakone.Seconds(900), Note: "DNF"})
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.StatusDNF, status)
+}
+
+func TestStatusOf_JapaneseNote(t *testing.T) {
+	status, err := statusOf(hakone.Record{TimeOf5km: hakone.Seconds(900), Note: "途中棄権"})
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.StatusDNF, status)
+}
+
+func TestStatusOf_UnknownNote(t *testing.T) {
+	_, err := statusOf(hakone.Record{Runner: "a", FinishTime: hakone.Seconds(3600), Note: "XYZ"})
+	assert.NotNil(t, err)
+	_, err = statusOf(hakone.Record{Runner: "a", Note: "XYZ"})
+	assert.NotNil(t, err)
+}
@@ -1,4 +1,4 @@
-package main
+package parser
 
 import (
 	bytes2 "bytes"
@@ -9,6 +9,8 @@ type StrType int
 
 const (
 	String StrType = iota
+	PlaceValue
+	BibValue
 	RunnerNameValue
 	GradeValue
 	TeamNameValue
@@ -18,6 +20,8 @@ const (
 	Time20km
 	ResultTime
 	Notes
+	RomanizedNameValue
+	NationalityValue
 	Rap5kmTo10km
 	Rap10kmTo15km
 	Rap15kmTo20km
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"github.com/ledongthuc/pdf"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type ColumnKey int
+
+const (
+	ColumnPlace ColumnKey = iota
+	ColumnBib
+	ColumnRunner
+	ColumnGrade
+	ColumnTeam
+	Column5km
+	Column10km
+	Column15km
+	Column20km
+	ColumnFinish
+	ColumnNetTime
+	ColumnNote
+)
+
+// Column is a range of X axis detected from the header row.
+type Column struct {
+	Key  ColumnKey
+	From float64
+	To   float64
+}
+
+func (c Column) contains(x float64) bool {
+	return c.From <= x && x < c.To
+}
+
+// Row is a line of the table, each cell is keyed by its column.
+type Row struct {
+	YAxis float64
+	Cells map[ColumnKey]string
+}
+
+func (r Row) Cell(key ColumnKey) string {
+	return r.Cells[key]
+}
+
+// String joins the cells in the order of columns.
+func (r Row) String() string {
+	values := make([]string, 0, len(r.Cells))
+	for key := ColumnPlace; key <= ColumnNote; key++ {
+		if value := r.Cell(key); value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, " ")
+}
+
+func (r Row) isEmpty() bool {
+	return len(r.Cells) == 0
+}
+
+type Table struct {
+	Columns []Column
+	Rows    []Row
+}
+
+// ExtractTable groups texts into rows by Y axis and into columns by X ranges detected from the header row.
+// Rows above the header row and separator rows are discarded.
+func ExtractTable(texts []pdf.Text, layout Layout) (Table, error) {
+	lines := GroupLines(validTexts(texts), layout.RowTolerance)
+	headerIndex := -1
+	var columns []Column
+	for index, line := range lines {
+		cols, ok := DetectColumns(line, layout.Headers, layout.OptionalHeaders)
+		if ok {
+			headerIndex = index
+			columns = cols
+			break
+		}
+	}
+	if headerIndex < 0 {
+		return Table{}, errors.Errorf("header row not found with headers %v", layout.Headers)
+	}
+
+	rows := make([]Row, 0)
+	for _, line := range lines[headerIndex+1:] {
+		row := toRow(line, columns, layout.HeaderSeparator)
+		if row.isEmpty() {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return Table{Columns: columns, Rows: rows}, nil
+}
+
+// validTexts drops replacement characters and duplicated glyphs which have the same position.
+func validTexts(texts []pdf.Text) []pdf.Text {
+	result := make([]pdf.Text, 0, len(texts))
+	for index, text := range texts {
+		if text.S == string(unicode.ReplacementChar) || strings.TrimSpace(text.S) == "" {
+			continue
+		}
+		if index > 0 {
+			prev := texts[index-1]
+			if prev.X == text.X && prev.Y == text.Y && prev.S == text.S {
+				continue
+			}
+		}
+		result = append(result, text)
+	}
+	return result
+}
+
+// GroupLines groups texts whose Y axis differs less than the tolerance, lines keep the order of appearance.
+func GroupLines(texts []pdf.Text, tolerance float64) [][]pdf.Text {
+	lines := make([][]pdf.Text, 0)
+	yAxes := make([]float64, 0)
+	for _, text := range texts {
+		found := false
+		for index, y := range yAxes {
+			if math.Abs(y-text.Y) <= tolerance {
+				lines[index] = append(lines[index], text)
+				found = true
+				break
+			}
+		}
+		if !found {
+			yAxes = append(yAxes, text.Y)
+			lines = append(lines, []pdf.Text{text})
+		}
+	}
+	for _, line := range lines {
+		sort.SliceStable(line, func(i, j int) bool {
+			return line[i].X < line[j].X
+		})
+	}
+	return lines
+}
+
+// DetectColumns finds every header label in the line, boundaries of columns are midpoints between label centers.
+// Optional headers are used only when the line has them.
+func DetectColumns(line []pdf.Text, headers map[ColumnKey]string, optionalHeaders map[ColumnKey]string) ([]Column, bool) {
+	if len(headers) == 0 {
+		return nil, false
+	}
+	type label struct {
+		key    ColumnKey
+		center float64
+	}
+	labels := make([]label, 0, len(headers))
+	for key, header := range headers {
+		center, ok := findLabelCenter(line, header)
+		if !ok {
+			return nil, false
+		}
+		labels = append(labels, label{key: key, center: center})
+	}
+	for key, header := range optionalHeaders {
+		if center, ok := findLabelCenter(line, header); ok {
+			labels = append(labels, label{key: key, center: center})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].center < labels[j].center
+	})
+	columns := make([]Column, len(labels))
+	for index, l := range labels {
+		from := math.Inf(-1)
+		to := math.Inf(1)
+		if index > 0 {
+			from = (labels[index-1].center + l.center) / 2
+		}
+		if index < len(labels)-1 {
+			to = (l.center + labels[index+1].center) / 2
+		}
+		columns[index] = Column{Key: l.key, From: from, To: to}
+	}
+	return columns, true
+}
+
+func findLabelCenter(line []pdf.Text, header string) (float64, bool) {
+	chars := []rune(removeSpaces(header))
+	if len(chars) == 0 {
+		return 0, false
+	}
+	glyphs := make([]pdf.Text, 0, len(line))
+	for _, text := range line {
+		if removeSpaces(text.S) != "" {
+			glyphs = append(glyphs, text)
+		}
+	}
+	for start := 0; start+len(chars) <= len(glyphs); start++ {
+		var sb strings.Builder
+		end := start
+		for ; end < len(glyphs) && len([]rune(sb.String())) < len(chars); end++ {
+			sb.WriteString(removeSpaces(glyphs[end].S))
+		}
+		if sb.String() == string(chars) {
+			first := glyphs[start]
+			last := glyphs[end-1]
+			return (first.X + last.X + last.W) / 2, true
+		}
+	}
+	return 0, false
+}
+
+func removeSpaces(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func toRow(line []pdf.Text, columns []Column, separator string) Row {
+	builders := make(map[ColumnKey]*strings.Builder)
+	onlySeparator := true
+	for _, text := range line {
+		if text.S != separator {
+			onlySeparator = false
+		}
+		for _, column := range columns {
+			if column.contains(text.X) {
+				sb, ok := builders[column.Key]
+				if !ok {
+					sb = &strings.Builder{}
+					builders[column.Key] = sb
+				}
+				sb.WriteString(text.S)
+				break
+			}
+		}
+	}
+	cells := make(map[ColumnKey]string)
+	if onlySeparator {
+		return Row{Cells: cells}
+	}
+	for key, sb := range builders {
+		cells[key] = strings.TrimSpace(sb.String())
+	}
+	var yAxis float64
+	if len(line) > 0 {
+		yAxis = line[0].Y
+	}
+	return Row{YAxis: yAxis, Cells: cells}
+}
@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"github.com/ledongthuc/pdf"
+	"github.com/mike-neck/go-hakone-qualification/hakone"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func glyphs(x, y float64, value string) []pdf.Text {
+	texts := make([]pdf.Text, 0)
+	for i, r := range []rune(value) {
+		xAxis := x + float64(i)*1.0
+		texts = append(texts, makeText(xAxis, y, string(r)), makeBytes(xAxis, y))
+	}
+	return texts
+}
+
+func line(cells ...[]pdf.Text) []pdf.Text {
+	texts := make([]pdf.Text, 0)
+	for _, cell := range cells {
+		texts = append(texts, cell...)
+	}
+	return texts
+}
+
+func tablePage() []pdf.Text {
+	return line(
+		glyphs(1.0, 90.0, "2019年10月26日"),
+		glyphs(1.0, 80.0, "順位"),
+		glyphs(5.0, 80.0, "ナンバー"),
+		glyphs(14.0, 80.0, "氏　名"),
+		glyphs(24.0, 80.0, "学年"),
+		glyphs(30.0, 80.0, "大学名"),
+		glyphs(45.0, 80.0, "5km"),
+		glyphs(55.0, 80.0, "10km"),
+		glyphs(65.0, 80.0, "15km"),
+		glyphs(75.0, 80.0, "20km"),
+		glyphs(85.0, 80.0, "記録"),
+		glyphs(97.0, 80.0, "備考"),
+		glyphs(1.0, 78.0, "----------------------------------------"),
+		// runner whose name has latin letters and digits
+		glyphs(1.0, 70.0, "1"),
+		glyphs(6.0, 70.0, "42"),
+		glyphs(11.0, 70.0, "J.MWANGI2"),
+		glyphs(23.0, 70.0, "(3)"),
+		glyphs(29.0, 70.0, "東京国際大"),
+		glyphs(44.0, 70.0, "14:47"),
+		glyphs(54.0, 70.0, "29:29"),
+		glyphs(64.0, 70.0, "44:20"),
+		glyphs(74.0, 70.0, "59:09"),
+		glyphs(83.0, 70.1, "1:02:23"),
+		glyphs(11.0, 68.0, "MWANGI"),
+		glyphs(29.0, 68.0, "ケニア"),
+		glyphs(53.0, 68.0, "(14:42)"),
+		glyphs(63.0, 68.0, "(14:51)"),
+		glyphs(73.0, 68.0, "(14:49)"),
+		// runner who did not start
+		glyphs(6.0, 60.0, "108"),
+		glyphs(11.0, 60.0, "山田太郎"),
+		glyphs(23.0, 60.0, "(1)"),
+		glyphs(29.0, 60.0, "東洋大"),
+		glyphs(96.0, 60.0, "DNS"),
+		glyphs(11.0, 58.0, "YAMADA"),
+	)
+}
+
+func TestGroupLines(t *testing.T) {
+	texts := []pdf.Text{
+		makeText(3.0, 10.0, "b"),
+		makeText(1.0, 10.2, "a"),
+		makeText(1.0, 8.0, "c"),
+	}
+
+	lines := GroupLines(texts, 0.5)
+
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, "a", lines[0][0].S)
+	assert.Equal(t, "b", lines[0][1].S)
+	assert.Equal(t, "c", lines[1][0].S)
+}
+
+func TestExtractTable(t *testing.T) {
+	table, err := ExtractTable(tablePage(), ColumnLayout)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 11, len(table.Columns))
+	assert.Equal(t, 4, len(table.Rows))
+	if len(table.Rows) != 4 {
+		return
+	}
+	first := table.Rows[0]
+	assert.Equal(t, "1", first.Cell(ColumnPlace))
+	assert.Equal(t, "42", first.Cell(ColumnBib))
+	assert.Equal(t, "J.MWANGI2", first.Cell(ColumnRunner))
+	assert.Equal(t, "(3)", first.Cell(ColumnGrade))
+	assert.Equal(t, "東京国際大", first.Cell(ColumnTeam))
+	assert.Equal(t, "14:47", first.Cell(Column5km))
+	assert.Equal(t, "1:02:23", first.Cell(ColumnFinish))
+	assert.Equal(t, "", first.Cell(ColumnNote))
+	second := table.Rows[1]
+	assert.Equal(t, "(14:42)", second.Cell(Column10km))
+}
+
+func TestExtractTable_NoHeader(t *testing.T) {
+	_, err := ExtractTable(glyphs(1.0, 70.0, "1山田太郎"), ColumnLayout)
+	assert.NotNil(t, err)
+}
+
+func TestBuildRecord(t *testing.T) {
+	table, err := ExtractTable(tablePage(), ColumnLayout)
+	assert.Nil(t, err)
+
+	rows := GroupRecordRows(table.Rows)
+	assert.Equal(t, 2, len(rows))
+	if len(rows) != 2 {
+		return
+	}
+
+	record, err := BuildRecord(rows[0])
+	assert.Nil(t, err)
+	assert.Equal(t, 1, record.Place)
+	assert.Equal(t, 42, record.Bib)
+	assert.Equal(t, "MWANGI", record.RomanizedName)
+	assert.Equal(t, "ケニア", record.Nationality)
+	assert.Equal(t, hakone.Runner("J.MWANGI2"), record.Runner)
+	assert.Equal(t, hakone.Grade(3), record.Grade)
+	assert.Equal(t, hakone.TeamName("東京国際大"), record.Team)
+	assert.Equal(t, hakone.Seconds(14*60+47), record.TimeOf5km)
+	assert.Equal(t, hakone.Seconds(59*60+9), record.TimeOf20km)
+	assert.Equal(t, hakone.Seconds(62*60+23), record.FinishTime)
+	assert.Equal(t, hakone.Seconds(14*60+42), record.RapFrom5kmTo10km)
+	assert.Equal(t, hakone.Seconds(14*60+49), record.RapFrom15kmTo20km)
+
+	dns, err := BuildRecord(rows[1])
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.Runner("山田太郎"), dns.Runner)
+	assert.Equal(t, 108, dns.Bib)
+	assert.Equal(t, "YAMADA", dns.RomanizedName)
+	assert.Equal(t, hakone.Note("DNS"), dns.Note)
+	assert.Equal(t, hakone.StatusDNS, dns.Status)
+	assert.Equal(t, hakone.Seconds(0), dns.FinishTime)
+}
+
+func TestParser_ParsePage_Columns(t *testing.T) {
+	p := Parser{Layout: ColumnLayout}
+
+	records, err := p.ParsePage(1, tablePage())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+	if len(records) != 2 {
+		return
+	}
+	assert.Equal(t, hakone.StatusFinished, records[0].Status)
+	assert.Equal(t, hakone.StatusDNS, records[1].Status)
+}
+
+func TestExtractTable_WithNetTime(t *testing.T) {
+	page := line(
+		glyphs(1.0, 80.0, "順位"),
+		glyphs(5.0, 80.0, "ナンバー"),
+		glyphs(14.0, 80.0, "氏　名"),
+		glyphs(24.0, 80.0, "学年"),
+		glyphs(30.0, 80.0, "大学名"),
+		glyphs(45.0, 80.0, "5km"),
+		glyphs(55.0, 80.0, "10km"),
+		glyphs(65.0, 80.0, "15km"),
+		glyphs(75.0, 80.0, "20km"),
+		glyphs(85.0, 80.0, "記録"),
+		glyphs(95.0, 80.0, "ネットタイム"),
+		glyphs(110.0, 80.0, "備考"),
+		glyphs(1.0, 70.0, "1"),
+		glyphs(6.0, 70.0, "42"),
+		glyphs(11.0, 70.0, "山田太郎"),
+		glyphs(23.0, 70.0, "(3)"),
+		glyphs(29.0, 70.0, "東洋大"),
+		glyphs(83.0, 70.0, "1:02:23"),
+		glyphs(94.0, 70.0, "1:02:20.5"),
+	)
+
+	table, err := ExtractTable(page, ColumnLayout)
+	assert.Nil(t, err)
+	assert.Equal(t, 12, len(table.Columns))
+
+	record, err := BuildRecord(GroupRecordRows(table.Rows)[0])
+	assert.Nil(t, err)
+	assert.Equal(t, hakone.Seconds(62*60+23), record.FinishTime)
+	assert.Equal(t, hakone.Seconds(62*60+20)+500*hakone.Millisecond, record.NetTime)
+}